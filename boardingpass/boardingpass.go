@@ -0,0 +1,132 @@
+// Package boardingpass issues and checks signed boarding pass tokens.
+//
+// A token carries the receipt, departure, seat and passenger it was issued
+// for, signed with the TicketService's Ed25519 key, so a conductor holding the
+// public key can check a pass without reaching the server. Tokens are compact
+// enough to print as a QR code.
+package boardingpass
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// tokenPrefix versions the token format.
+const tokenPrefix = "BP1"
+
+var (
+	// ErrMalformed is returned for tokens that are not boarding passes.
+	ErrMalformed = errors.New("malformed boarding pass token")
+	// ErrBadSignature is returned for tokens not signed by the expected key.
+	ErrBadSignature = errors.New("boarding pass signature does not match")
+)
+
+// Pass is what a boarding pass token vouches for.
+type Pass struct {
+	ReceiptID   string `json:"r"`
+	DepartureID string `json:"d"`
+	Seat        string `json:"s"`
+	Passenger   string `json:"p"` // the passenger's email
+}
+
+var encoding = base64.RawURLEncoding
+
+// Sign returns a token for p signed with key, of the form
+// BP1.<payload>.<signature>.
+func Sign(key ed25519.PrivateKey, p Pass) (string, error) {
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return "", err
+	}
+	signed := tokenPrefix + "." + encoding.EncodeToString(payload)
+	return signed + "." + encoding.EncodeToString(ed25519.Sign(key, []byte(signed))), nil
+}
+
+// Verify checks that token was signed by the holder of key and returns the
+// pass it carries.
+func Verify(key ed25519.PublicKey, token string) (*Pass, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenPrefix {
+		return nil, ErrMalformed
+	}
+	sig, err := encoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	if !ed25519.Verify(key, []byte(parts[0]+"."+parts[1]), sig) {
+		return nil, ErrBadSignature
+	}
+	payload, err := encoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	p := &Pass{}
+	if err := json.Unmarshal(payload, p); err != nil {
+		return nil, ErrMalformed
+	}
+	return p, nil
+}
+
+// QRCode renders token as a PNG QR code size pixels wide.
+func QRCode(token string, size int) ([]byte, error) {
+	return qrcode.Encode(token, qrcode.Medium, size)
+}
+
+// QRText renders token as a QR code drawn with block characters, for
+// terminals.
+func QRText(token string) (string, error) {
+	q, err := qrcode.New(token, qrcode.Medium)
+	if err != nil {
+		return "", err
+	}
+	return q.ToSmallString(false), nil
+}
+
+// EncodePublicKey returns key as hex, the form conductors are given.
+func EncodePublicKey(key ed25519.PublicKey) string {
+	return hex.EncodeToString(key)
+}
+
+// DecodePublicKey parses a key written by EncodePublicKey.
+func DecodePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid boarding pass public key %q", s)
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// LoadKey reads a signing key from path, where it is kept as a hex-encoded
+// seed. If path does not exist a new key is generated and written there, so
+// passes stay valid across restarts.
+func LoadKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, []byte(hex.EncodeToString(key.Seed())+"\n"), 0600); err != nil {
+			return nil, err
+		}
+		return key, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	seed, err := hex.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("boarding pass key %s is not a hex-encoded Ed25519 seed", path)
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
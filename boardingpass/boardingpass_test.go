@@ -0,0 +1,68 @@
+package boardingpass
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	pub, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	otherPub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	pass := Pass{ReceiptID: "rec-1", DepartureID: "ES9010-20300901", Seat: "A1", Passenger: "john.doe@example.com"}
+
+	token, err := Sign(key, pass)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, "BP1."))
+	verified, err := Verify(pub, token)
+	require.NoError(t, err)
+	assert.Equal(t, pass, *verified)
+
+	_, err = Verify(otherPub, token)
+	assert.ErrorIs(t, err, ErrBadSignature)
+
+	// Changing the seat invalidates the signature
+	forged, err := Sign(key, Pass{ReceiptID: "rec-1", DepartureID: "ES9010-20300901", Seat: "A2", Passenger: "john.doe@example.com"})
+	require.NoError(t, err)
+	parts, forgedParts := strings.Split(token, "."), strings.Split(forged, ".")
+	_, err = Verify(pub, strings.Join([]string{forgedParts[0], forgedParts[1], parts[2]}, "."))
+	assert.ErrorIs(t, err, ErrBadSignature)
+
+	for _, bad := range []string{"", "BP1.abc", "XX1.a.b", "BP1.a.!!"} {
+		_, err = Verify(pub, bad)
+		assert.ErrorIs(t, err, ErrMalformed, bad)
+	}
+}
+
+func TestQRCode(t *testing.T) {
+	png, err := QRCode("BP1.payload.signature", 256)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(png, []byte("\x89PNG")))
+
+	text, err := QRText("BP1.payload.signature")
+	require.NoError(t, err)
+	assert.Contains(t, text, "█")
+}
+
+func TestLoadKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "boarding.key")
+	key, err := LoadKey(path)
+	require.NoError(t, err)
+	again, err := LoadKey(path)
+	require.NoError(t, err)
+	assert.True(t, key.Equal(again))
+
+	pub, err := DecodePublicKey(EncodePublicKey(key.Public().(ed25519.PublicKey)))
+	require.NoError(t, err)
+	assert.True(t, pub.Equal(key.Public()))
+	_, err = DecodePublicKey("abc")
+	assert.Error(t, err)
+}
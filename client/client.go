@@ -1,15 +1,29 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"encoding/csv"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Aravinthvvs/gRPC/boardingpass"
+	"github.com/Aravinthvvs/gRPC/money"
 	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 // Client wraps the gRPC client
@@ -25,11 +39,32 @@ func NewClient(conn *grpc.ClientConn) *Client {
 }
 
 // PurchaseTicket is a method to call the PurchaseTicket gRPC method
-func (c *Client) PurchaseTicket(ctx context.Context, from, to string, user *pb.User) (*pb.PurchaseResponse, error) {
+// section and seat are optional preferences and may be empty, as may
+// currency to pay in the server's default currency. paymentToken identifies
+// the card or wallet to charge.
+func (c *Client) PurchaseTicket(ctx context.Context, from, to, departureID string, user *pb.User, section, seat, currency, paymentToken string) (*pb.PurchaseResponse, error) {
 	return c.client.PurchaseTicket(ctx, &pb.PurchaseRequest{
-		From: from,
-		To:   to,
-		User: user,
+		From:             from,
+		To:               to,
+		DepartureId:      departureID,
+		User:             user,
+		PreferredSection: section,
+		PreferredSeat:    seat,
+		CurrencyCode:     currency,
+		PaymentToken:     paymentToken,
+	})
+}
+
+// PurchaseGroupTicket books one ticket seating every passenger together.
+// user is the booker and is normally one of the passengers.
+func (c *Client) PurchaseGroupTicket(ctx context.Context, from, to, departureID string, user *pb.User, passengers []*pb.User, paymentToken string) (*pb.PurchaseResponse, error) {
+	return c.client.PurchaseTicket(ctx, &pb.PurchaseRequest{
+		From:         from,
+		To:           to,
+		DepartureId:  departureID,
+		User:         user,
+		Passengers:   passengers,
+		PaymentToken: paymentToken,
 	})
 }
 
@@ -43,26 +78,622 @@ func (c *Client) RemoveUser(ctx context.Context, email string) (*pb.RemoveUserRe
 	return c.client.RemoveUser(ctx, req)
 }
 
-func (c *Client) ModifySeat(ctx context.Context, email, newSeat string) (*pb.ModifySeatResponse, error) {
-	req := &pb.ModifySeatRequest{Email: email, NewSeat: newSeat}
+// ModifySeat moves the passenger in currentSeat to newSeat. currentSeat may be
+// empty when the ticket holds a single seat.
+func (c *Client) ModifySeat(ctx context.Context, receiptID, currentSeat, newSeat string) (*pb.ModifySeatResponse, error) {
+	req := &pb.ModifySeatRequest{ReceiptId: receiptID, CurrentSeat: currentSeat, NewSeat: newSeat}
 	return c.client.ModifySeat(ctx, req)
 }
 
-func (c *Client) ViewUsersBySection(ctx context.Context, section string) (*pb.ViewUsersResponse, error) {
-	req := &pb.ViewUsersRequest{Section: section}
-	return c.client.ViewUsersBySection(ctx, req)
+func (c *Client) CancelTicket(ctx context.Context, receiptID string) (*pb.CancelTicketResponse, error) {
+	req := &pb.CancelTicketRequest{ReceiptId: receiptID}
+	return c.client.CancelTicket(ctx, req)
+}
+
+// ListMyTickets fetches every ticket email holds that matches filter, which
+// may be empty.
+func (c *Client) ListMyTickets(ctx context.Context, email, filter string) (*pb.ListMyTicketsResponse, error) {
+	resp := &pb.ListMyTicketsResponse{}
+	err := allPages(func(pageToken string) (string, error) {
+		page, err := c.client.ListMyTickets(ctx, &pb.ListMyTicketsRequest{Email: email, Filter: filter, PageToken: pageToken})
+		if err != nil {
+			return "", err
+		}
+		resp.Receipts = append(resp.Receipts, page.Receipts...)
+		resp.TotalSize = page.TotalSize
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// allPages calls list with each page token in turn, starting with none,
+// until the server has no more pages.
+func allPages(list func(pageToken string) (string, error)) error {
+	pageToken := ""
+	for {
+		next, err := list(pageToken)
+		if err != nil || next == "" {
+			return err
+		}
+		pageToken = next
+	}
+}
+
+// HoldSeats keeps seats on departureID free for a later purchase booked by
+// email.
+func (c *Client) HoldSeats(ctx context.Context, departureID, email string, seats []string) (*pb.HoldSeatsResponse, error) {
+	req := &pb.HoldSeatsRequest{DepartureId: departureID, Seats: seats, Email: email}
+	return c.client.HoldSeats(ctx, req)
+}
+
+func (c *Client) ReleaseHold(ctx context.Context, holdID, email string) (*pb.ReleaseHoldResponse, error) {
+	req := &pb.ReleaseHoldRequest{HoldId: holdID, Email: email}
+	return c.client.ReleaseHold(ctx, req)
+}
+
+// PurchaseHeldSeats books the seats of holdID, one per passenger in order.
+func (c *Client) PurchaseHeldSeats(ctx context.Context, holdID, from, to, departureID string, user *pb.User, passengers []*pb.User, paymentToken string) (*pb.PurchaseResponse, error) {
+	return c.client.PurchaseTicket(ctx, &pb.PurchaseRequest{
+		From:         from,
+		To:           to,
+		DepartureId:  departureID,
+		User:         user,
+		Passengers:   passengers,
+		PaymentToken: paymentToken,
+		HoldId:       holdID,
+	})
+}
+
+// JoinWaitlist queues user for a seat on a sold-out departure. section may be
+// empty if any section will do.
+func (c *Client) JoinWaitlist(ctx context.Context, departureID, section string, user *pb.User, priority int32) (*pb.JoinWaitlistResponse, error) {
+	req := &pb.JoinWaitlistRequest{DepartureId: departureID, Section: section, User: user, Priority: priority}
+	return c.client.JoinWaitlist(ctx, req)
+}
+
+func (c *Client) LeaveWaitlist(ctx context.Context, entryID, email string) (*pb.LeaveWaitlistResponse, error) {
+	req := &pb.LeaveWaitlistRequest{EntryId: entryID, Email: email}
+	return c.client.LeaveWaitlist(ctx, req)
+}
+
+// WatchWaitlist calls fn with each update to the waitlist entry entryID, made
+// for email, until the entry stops waiting.
+func (c *Client) WatchWaitlist(ctx context.Context, entryID, email string, fn func(*pb.WaitlistEntry)) error {
+	stream, err := c.client.WatchWaitlist(ctx, &pb.WatchWaitlistRequest{EntryId: entryID, Email: email})
+	if err != nil {
+		return err
+	}
+	for {
+		entry, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fn(entry)
+	}
+}
+
+// BulkPurchase streams purchases to the server, which buys them in order, and
+// returns its report on every one.
+func (c *Client) BulkPurchase(ctx context.Context, reqs []*pb.PurchaseRequest) (*pb.BulkPurchaseResponse, error) {
+	stream, err := c.client.BulkPurchase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, req := range reqs {
+		// io.EOF means the server ended the stream; CloseAndRecv says why
+		if err := stream.Send(req); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+// SeatSelectionSession opens a seat selection session on departureID for the
+// booker email, sends each action read from actions, and calls fn with each
+// event until the server ends the session. Closing actions leaves the
+// session, giving up any seats still held.
+func (c *Client) SeatSelectionSession(ctx context.Context, departureID, email string, actions <-chan *pb.SeatSelectionRequest, fn func(*pb.SeatSelectionEvent)) error {
+	stream, err := c.client.SeatSelectionSession(ctx)
+	if err != nil {
+		return err
+	}
+	start := &pb.SeatSelectionRequest{Action: &pb.SeatSelectionRequest_Start{Start: &pb.StartSelection{DepartureId: departureID, Email: email}}}
+	if err := stream.Send(start); err != nil && err != io.EOF {
+		return err
+	}
+	go func() {
+		for action := range actions {
+			// Recv reports why the stream ended
+			if err := stream.Send(action); err != nil {
+				return
+			}
+		}
+		stream.CloseSend()
+	}()
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fn(event)
+	}
+}
+
+// seatMapRetryDelay is how long WatchSeatMap waits before reconnecting.
+var seatMapRetryDelay = time.Second
+
+// WatchSeatMap calls fn with a snapshot of the seats on departureID and then
+// with each change, until ctx is done. If the connection drops it reconnects
+// with the last resume token, so no change is missed.
+func (c *Client) WatchSeatMap(ctx context.Context, departureID string, fn func(*pb.SeatMapEvent)) error {
+	resumeToken := ""
+	for {
+		err := c.watchSeatMap(ctx, departureID, &resumeToken, fn)
+		if status.Code(err) != codes.Unavailable {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(seatMapRetryDelay):
+		}
+	}
+}
+
+// watchSeatMap watches departureID once, from *resumeToken if it is set, and
+// keeps *resumeToken up to date with the events received.
+func (c *Client) watchSeatMap(ctx context.Context, departureID string, resumeToken *string, fn func(*pb.SeatMapEvent)) error {
+	stream, err := c.client.WatchSeatMap(ctx, &pb.WatchSeatMapRequest{DepartureId: departureID, ResumeToken: *resumeToken})
+	if err != nil {
+		return err
+	}
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		*resumeToken = event.ResumeToken
+		fn(event)
+	}
+}
+
+// GetBoardingPass fetches the boarding pass for the passenger in seat, which
+// may be empty when the ticket holds a single seat.
+func (c *Client) GetBoardingPass(ctx context.Context, receiptID, seat string) (*pb.BoardingPass, error) {
+	req := &pb.GetBoardingPassRequest{ReceiptId: receiptID, Seat: seat}
+	return c.client.GetBoardingPass(ctx, req)
+}
+
+// ValidateTicket checks in the passenger whose boarding pass carries token.
+func (c *Client) ValidateTicket(ctx context.Context, departureID, token string) (*pb.ValidateTicketResponse, error) {
+	req := &pb.ValidateTicketRequest{DepartureId: departureID, Token: token}
+	return c.client.ValidateTicket(ctx, req)
+}
+
+// RenderReceipt fetches a printable copy of a receipt in format.
+func (c *Client) RenderReceipt(ctx context.Context, receiptID string, format pb.ReceiptFormat) (*pb.RenderReceiptResponse, error) {
+	req := &pb.RenderReceiptRequest{ReceiptId: receiptID, Format: format}
+	return c.client.RenderReceipt(ctx, req)
+}
+
+// ViewUsersBySection fetches the whole manifest of a section, narrowed to a
+// departure, route or filter when they are not empty.
+func (c *Client) ViewUsersBySection(ctx context.Context, section, departureID, routeID string, order pb.ManifestOrder, filter string) (*pb.ViewUsersResponse, error) {
+	resp := &pb.ViewUsersResponse{}
+	err := allPages(func(pageToken string) (string, error) {
+		page, err := c.client.ViewUsersBySection(ctx, &pb.ViewUsersRequest{
+			Section:     section,
+			DepartureId: departureID,
+			RouteId:     routeID,
+			OrderBy:     order,
+			Filter:      filter,
+			PageToken:   pageToken,
+		})
+		if err != nil {
+			return "", err
+		}
+		resp.UserSeats = append(resp.UserSeats, page.UserSeats...)
+		resp.TotalSize = page.TotalSize
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListStations fetches every station matching filter, which may be empty.
+func (c *Client) ListStations(ctx context.Context, filter string) (*pb.ListStationsResponse, error) {
+	resp := &pb.ListStationsResponse{}
+	err := allPages(func(pageToken string) (string, error) {
+		page, err := c.client.ListStations(ctx, &pb.ListStationsRequest{Filter: filter, PageToken: pageToken})
+		if err != nil {
+			return "", err
+		}
+		resp.Stations = append(resp.Stations, page.Stations...)
+		resp.TotalSize = page.TotalSize
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListDepartures fetches every departure from one station to another on date
+// that matches filter, which may be empty.
+func (c *Client) ListDepartures(ctx context.Context, from, to, date, filter string) (*pb.ListDeparturesResponse, error) {
+	resp := &pb.ListDeparturesResponse{}
+	err := allPages(func(pageToken string) (string, error) {
+		page, err := c.client.ListDepartures(ctx, &pb.ListDeparturesRequest{From: from, To: to, Date: date, Filter: filter, PageToken: pageToken})
+		if err != nil {
+			return "", err
+		}
+		resp.Departures = append(resp.Departures, page.Departures...)
+		resp.TotalSize = page.TotalSize
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// describeError renders a gRPC error as its status code and message followed
+// by any structured details the server attached.
+func describeError(err error) string {
+	st := status.Convert(err)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: %s", st.Code(), st.Message())
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.FieldViolations {
+				fmt.Fprintf(&b, "\n  invalid %s: %s", v.Field, v.Description)
+			}
+		case *errdetails.ErrorInfo:
+			fmt.Fprintf(&b, "\n  reason: %s", d.Reason)
+			keys := make([]string, 0, len(d.Metadata))
+			for k := range d.Metadata {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				fmt.Fprintf(&b, "\n  %s: %s", k, d.Metadata[k])
+			}
+		}
+	}
+	return b.String()
+}
+
+// parsePassengers reads passengers from first name, last name and email
+// triples.
+func parsePassengers(args []string) []*pb.User {
+	var passengers []*pb.User
+	for i := 0; i+2 < len(args); i += 3 {
+		passengers = append(passengers, &pb.User{
+			FirstName: args[i],
+			LastName:  args[i+1],
+			Email:     args[i+2],
+		})
+	}
+	return passengers
+}
+
+// bookingColumns are the columns of a bookings CSV file. Those not required
+// may be left out.
+var bookingColumns = map[string]bool{
+	"from": true, "to": true, "departure_id": true, "first_name": true, "last_name": true, "email": true,
+	"passenger_type": false, "section": false, "seat": false, "currency": false, "payment_token": false,
+}
+
+// parseBookingsCSV reads one purchase per row from a CSV file whose header
+// names its columns, in any order. Rows without a payment_token are paid for
+// with paymentToken. It also returns the line each purchase was read from.
+func parseBookingsCSV(r io.Reader, paymentToken string) ([]*pb.PurchaseRequest, []int, error) {
+	records := csv.NewReader(r)
+	header, err := records.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := bookingColumns[name]; !ok {
+			return nil, nil, fmt.Errorf("unknown column %q", name)
+		}
+		columns[name] = i
+	}
+	for name, required := range bookingColumns {
+		if _, ok := columns[name]; required && !ok {
+			return nil, nil, fmt.Errorf("missing column %q", name)
+		}
+	}
+
+	var reqs []*pb.PurchaseRequest
+	var lines []int
+	for {
+		record, err := records.Read()
+		if err == io.EOF {
+			return reqs, lines, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		line, _ := records.FieldPos(0)
+		field := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		req := &pb.PurchaseRequest{
+			From:        field("from"),
+			To:          field("to"),
+			DepartureId: field("departure_id"),
+			User: &pb.User{
+				FirstName: field("first_name"),
+				LastName:  field("last_name"),
+				Email:     field("email"),
+			},
+			PreferredSection: field("section"),
+			PreferredSeat:    field("seat"),
+			CurrencyCode:     field("currency"),
+			PaymentToken:     field("payment_token"),
+		}
+		if passengerType := field("passenger_type"); passengerType != "" {
+			t, ok := pb.PassengerType_value["PASSENGER_TYPE_"+strings.ToUpper(passengerType)]
+			if !ok {
+				return nil, nil, fmt.Errorf("line %d: unknown passenger type %q, expected adult, child or senior", line, passengerType)
+			}
+			req.User.PassengerType = pb.PassengerType(t)
+		}
+		if req.PaymentToken == "" {
+			req.PaymentToken = paymentToken
+		}
+		reqs = append(reqs, req)
+		lines = append(lines, line)
+	}
+}
+
+// describeBulkPurchaseResult renders the outcome of one imported booking,
+// e.g. "line 3: FailedPrecondition: seat A1 ... is already taken (SEAT_TAKEN)".
+func describeBulkPurchaseResult(line int, result *pb.BulkPurchaseResult) string {
+	if result.Error == nil {
+		return fmt.Sprintf("line %d: %s", line, result.ReceiptId)
+	}
+	desc := fmt.Sprintf("line %d: %s: %s", line, result.Error.Code, result.Error.Message)
+	if result.Error.Reason != "" {
+		desc += fmt.Sprintf(" (%s)", result.Error.Reason)
+	}
+	if len(result.Error.Fields) > 0 {
+		desc += fmt.Sprintf(" [invalid %s]", strings.Join(result.Error.Fields, ", "))
+	}
+	return desc
+}
+
+// describeRefund renders a refund as one line, e.g.
+// "Refund rec-1-refund-1 for rec-1: £42.00 (100%, full)".
+func describeRefund(refund *pb.Refund) string {
+	return fmt.Sprintf("Refund %s for %s: %s (%d%%, %s)", refund.RefundId, refund.ReceiptId, money.Format(refund.Amount), refund.Percent, refund.Rule)
+}
+
+// describeWaitlistEntry renders a waitlist entry for display.
+func describeWaitlistEntry(entry *pb.WaitlistEntry) string {
+	switch entry.Status {
+	case pb.WaitlistStatus_WAITLIST_STATUS_WAITING:
+		return fmt.Sprintf("%s: waiting at position %d for %s", entry.EntryId, entry.Position, entry.DepartureId)
+	case pb.WaitlistStatus_WAITLIST_STATUS_PROMOTED:
+		return fmt.Sprintf("%s: seat %s is waiting on %s, buy it with purchase_held %s before %s", entry.EntryId,
+			strings.Join(entry.Hold.GetSeats(), " "), entry.DepartureId, entry.Hold.GetHoldId(), entry.Hold.GetExpiresAt().AsTime().Local().Format(time.Kitchen))
+	}
+	return fmt.Sprintf("%s: %s", entry.EntryId, strings.ToLower(strings.TrimPrefix(entry.Status.String(), "WAITLIST_STATUS_")))
+}
+
+// describeSeatMapEvent renders a seat map snapshot or change on one line,
+// e.g. "14:02:11 changed: A1 booked, B2 free".
+func describeSeatMapEvent(event *pb.SeatMapEvent) string {
+	var seats []string
+	for _, seat := range event.Seats {
+		seats = append(seats, seat.Seat+" "+strings.ToLower(strings.TrimPrefix(seat.State.String(), "SEAT_STATE_")))
+	}
+	kind := "changed"
+	if event.Snapshot {
+		kind = "seats"
+	}
+	return fmt.Sprintf("%s %s: %s", event.Time.AsTime().Local().Format("15:04:05"), kind, strings.Join(seats, ", "))
+}
+
+// parseSelectionAction reads an action typed into a seat selection session:
+// "pick <seat>", "unpick <seat>", or "confirm" followed by passenger triples,
+// the first of whom books the ticket from from to to.
+func parseSelectionAction(line, from, to, paymentToken string) (*pb.SeatSelectionRequest, error) {
+	words := strings.Fields(line)
+	if len(words) == 0 {
+		return nil, fmt.Errorf("expected pick, unpick or confirm")
+	}
+	switch words[0] {
+	case "pick", "unpick":
+		if len(words) != 2 {
+			return nil, fmt.Errorf("usage: %s <seat>", words[0])
+		}
+		if words[0] == "pick" {
+			return &pb.SeatSelectionRequest{Action: &pb.SeatSelectionRequest_Pick{Pick: words[1]}}, nil
+		}
+		return &pb.SeatSelectionRequest{Action: &pb.SeatSelectionRequest_Unpick{Unpick: words[1]}}, nil
+	case "confirm":
+		if len(words) < 4 || (len(words)-1)%3 != 0 {
+			return nil, fmt.Errorf("usage: confirm <first_name> <last_name> <email> [<first_name> <last_name> <email>]...")
+		}
+		passengers := parsePassengers(words[1:])
+		return &pb.SeatSelectionRequest{Action: &pb.SeatSelectionRequest_Confirm{Confirm: &pb.ConfirmSelection{
+			From:         from,
+			To:           to,
+			User:         passengers[0],
+			Passengers:   passengers,
+			PaymentToken: paymentToken,
+		}}}, nil
+	}
+	return nil, fmt.Errorf("unknown action %q, expected pick, unpick or confirm", words[0])
+}
+
+// describeSelectionEvent renders an event of a seat selection session on one
+// line, e.g. "Cannot pick A1: seat A1 ... is already taken (SEAT_TAKEN)".
+func describeSelectionEvent(event *pb.SeatSelectionEvent) string {
+	switch e := event.Event.(type) {
+	case *pb.SeatSelectionEvent_SeatMap:
+		return describeSeatMapEvent(e.SeatMap)
+	case *pb.SeatSelectionEvent_Hold:
+		if len(e.Hold.Seats) == 0 {
+			return "Holding no seats"
+		}
+		return fmt.Sprintf("Holding %s until %s", strings.Join(e.Hold.Seats, " "), e.Hold.ExpiresAt.AsTime().Local().Format(time.Kitchen))
+	case *pb.SeatSelectionEvent_Conflict:
+		return fmt.Sprintf("Cannot pick %s: %s (%s)", e.Conflict.Seat, e.Conflict.Message, e.Conflict.Reason)
+	case *pb.SeatSelectionEvent_Expiring:
+		return fmt.Sprintf("Hold on %s expires at %s, pick a seat to keep it", strings.Join(e.Expiring.Seats, " "), e.Expiring.ExpiresAt.AsTime().Local().Format(time.Kitchen))
+	case *pb.SeatSelectionEvent_Expired:
+		return fmt.Sprintf("Hold on %s expired, the seats were released", strings.Join(e.Expired.Seats, " "))
+	case *pb.SeatSelectionEvent_PurchaseFailed:
+		desc := fmt.Sprintf("Purchase failed: %s: %s", e.PurchaseFailed.Code, e.PurchaseFailed.Message)
+		if e.PurchaseFailed.Reason != "" {
+			desc += fmt.Sprintf(" (%s)", e.PurchaseFailed.Reason)
+		}
+		return desc
+	case *pb.SeatSelectionEvent_Confirmed:
+		return fmt.Sprintf("Purchase Response: %s", e.Confirmed.ReceiptId)
+	}
+	return "unknown event"
+}
+
+// describeBoardingPass renders the printed part of a boarding pass.
+func describeBoardingPass(pass *pb.BoardingPass) string {
+	return fmt.Sprintf("%s %s  %s -> %s  departs %s  %s %s  (%s)",
+		pass.Passenger.GetFirstName(), pass.Passenger.GetLastName(),
+		pass.From.GetName(), pass.To.GetName(), pass.BoardsAt.AsTime().Local().Format("2006-01-02 15:04"),
+		pass.Section, pass.Seat, pass.ReceiptId)
+}
+
+// parseReceiptFormat maps a --format name to a ReceiptFormat.
+func parseReceiptFormat(name string) (pb.ReceiptFormat, error) {
+	format, ok := pb.ReceiptFormat_value["RECEIPT_FORMAT_"+strings.ToUpper(name)]
+	if !ok || format == int32(pb.ReceiptFormat_RECEIPT_FORMAT_UNSPECIFIED) {
+		return 0, fmt.Errorf("unknown receipt format %q, expected text, html or pdf", name)
+	}
+	return pb.ReceiptFormat(format), nil
+}
+
+// parseManifestOrder maps a --sort name to a ManifestOrder.
+func parseManifestOrder(name string) (pb.ManifestOrder, error) {
+	order, ok := pb.ManifestOrder_value["MANIFEST_ORDER_"+strings.ToUpper(name)]
+	if !ok || order == int32(pb.ManifestOrder_MANIFEST_ORDER_UNSPECIFIED) {
+		return 0, fmt.Errorf("unknown sort order %q, expected seat or name", name)
+	}
+	return pb.ManifestOrder(order), nil
+}
+
+// describeUserSeat renders one line of a section manifest.
+func describeUserSeat(us *pb.UserSeat) string {
+	name := strings.TrimSpace(us.User.GetFirstName() + " " + us.User.GetLastName())
+	if name == "" {
+		name = "-"
+	}
+	return fmt.Sprintf("%-16s %-4s %-20s %-28s %-10s %s -> %s",
+		us.DepartureId, us.Seat, name, us.User.GetEmail(), us.ReceiptId, us.From.GetName(), us.To.GetName())
+}
+
+// parseArgs parses fs from a command's arguments, which may give flags before
+// or after its n positional arguments. It returns the positional arguments,
+// or nil if there are fewer than n.
+func parseArgs(fs *flag.FlagSet, args []string, n int) []string {
+	fs.Parse(args)
+	if fs.NArg() < n {
+		return nil
+	}
+	positional := fs.Args()[:n]
+	fs.Parse(fs.Args()[n:])
+	return positional
+}
+
+// bookingStatus renders a booking status for display, e.g. "cancelled".
+func bookingStatus(st pb.BookingStatus) string {
+	if st == pb.BookingStatus_BOOKING_STATUS_UNSPECIFIED {
+		st = pb.BookingStatus_BOOKING_STATUS_CONFIRMED
+	}
+	return strings.ToLower(strings.TrimPrefix(st.String(), "BOOKING_STATUS_"))
+}
+
+// tokenCredentials sends a bearer token, either a JWT or an API key, with
+// every call.
+type tokenCredentials struct {
+	token string
+	// insecure lets the token travel over a plaintext connection, as asked
+	// for with -insecure.
+	insecure bool
+}
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+// RequireTransportSecurity refuses to send the token in plaintext unless
+// -insecure was given.
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return !t.insecure
+}
+
+// transportCredentials returns the credentials the client dials with: TLS,
+// trusting the certificates in caFile if it is set and the system's
+// otherwise, or plaintext if insecure is set.
+func transportCredentials(caFile string, insecureConn bool) (credentials.TransportCredentials, error) {
+	if insecureConn {
+		return insecure.NewCredentials(), nil
+	}
+	if caFile != "" {
+		return credentials.NewClientTLSFromFile(caFile, "")
+	}
+	return credentials.NewClientTLSFromCert(nil, ""), nil
 }
 
 func main() {
+	// Options before the command apply to every command
+	token := flag.String("token", os.Getenv("TICKET_TOKEN"), "JWT or API key to authenticate with (default $TICKET_TOKEN)")
+	caFile := flag.String("ca", "", "PEM file with the certificates the server's TLS certificate is checked against (default: system roots)")
+	insecureConn := flag.Bool("insecure", false, "connect in plaintext, to a server without TLS")
+	flag.Parse()
+	os.Args = append(os.Args[:1], flag.Args()...)
+
 	// Parse command-line arguments
 	if len(os.Args) < 2 {
-		log.Fatalf("Usage: %s <command> [options]", os.Args[0])
+		log.Fatalf("Usage: %s [--token token] [--ca file | --insecure] <command> [options]", os.Args[0])
 	}
 
 	command := os.Args[1]
 
 	// Establish a connection to the server
-	conn, err := grpc.Dial("localhost:50056", grpc.WithInsecure(), grpc.WithBlock())
+	creds, err := transportCredentials(*caFile, *insecureConn)
+	if err != nil {
+		log.Fatalf("failed to load CA certificates: %v", err)
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds), grpc.WithBlock()}
+	if *token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCredentials{token: *token, insecure: *insecureConn}))
+	}
+	conn, err := grpc.Dial("localhost:50056", opts...)
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
 	}
@@ -74,47 +705,304 @@ func main() {
 
 	switch command {
 	case "purchase":
-		if len(os.Args) < 6 {
-			log.Fatalf("Usage: %s purchase <from> <to> <first_name> <last_name> <email>", os.Args[0])
+		if len(os.Args) < 8 {
+			log.Fatalf("Usage: %s purchase <from> <to> <first_name> <last_name> <email> <departure_id> [section|-] [seat|-] [currency]", os.Args[0])
 		}
 		from := os.Args[2]
 		to := os.Args[3]
 		firstName := os.Args[4]
 		lastName := os.Args[5]
 		email := os.Args[6]
+		departureID := os.Args[7]
+		var section, seat, currency string
+		if len(os.Args) > 8 && os.Args[8] != "-" {
+			section = os.Args[8]
+		}
+		if len(os.Args) > 9 && os.Args[9] != "-" {
+			seat = os.Args[9]
+		}
+		if len(os.Args) > 10 {
+			currency = os.Args[10]
+		}
 
 		user := &pb.User{
 			FirstName: firstName,
 			LastName:  lastName,
 			Email:     email,
 		}
-		resp, err := c.PurchaseTicket(ctx, from, to, user)
+		resp, err := c.PurchaseTicket(ctx, from, to, departureID, user, section, seat, currency, os.Getenv("PAYMENT_TOKEN"))
 		if err != nil {
-			log.Fatalf("could not purchase ticket: %v", err)
+			log.Fatalf("could not purchase ticket: %s", describeError(err))
 		}
 		fmt.Printf("Purchase Response: %s\n", resp.ReceiptId)
 
-	case "get_receipt":
+	case "import":
 		if len(os.Args) < 3 {
-			log.Fatalf("Usage: %s get_receipt <receipt_id>", os.Args[0])
+			log.Fatalf("Usage: %s import <bookings.csv>", os.Args[0])
+		}
+		f, err := os.Open(os.Args[2])
+		if err != nil {
+			log.Fatalf("could not open bookings: %v", err)
+		}
+		reqs, lines, err := parseBookingsCSV(f, os.Getenv("PAYMENT_TOKEN"))
+		f.Close()
+		if err != nil {
+			log.Fatalf("could not read %s: %v", os.Args[2], err)
 		}
-		receiptId := os.Args[2]
-		resp, err := c.GetReceipt(ctx, receiptId)
+		// Hundreds of purchases take longer than a single call
+		resp, err := c.BulkPurchase(context.Background(), reqs)
 		if err != nil {
-			log.Fatalf("could not get receipt: %v", err)
+			log.Fatalf("could not import bookings: %s", describeError(err))
+		}
+		for _, result := range resp.Results {
+			fmt.Println(describeBulkPurchaseResult(lines[result.Row-1], result))
+		}
+		fmt.Printf("Imported %d of %d bookings, %d failed.\n", resp.Succeeded, len(reqs), resp.Failed)
+		if resp.Failed > 0 {
+			os.Exit(1)
 		}
-		fmt.Printf("Receipt: %+v\n", resp)
 
-	case "view_users":
+	case "purchase_group":
+		if len(os.Args) < 8 || (len(os.Args)-5)%3 != 0 {
+			log.Fatalf("Usage: %s purchase_group <from> <to> <departure_id> <first_name> <last_name> <email> [<first_name> <last_name> <email>]...", os.Args[0])
+		}
+		from := os.Args[2]
+		to := os.Args[3]
+		departureID := os.Args[4]
+
+		// The first passenger books the ticket
+		passengers := parsePassengers(os.Args[5:])
+		resp, err := c.PurchaseGroupTicket(ctx, from, to, departureID, passengers[0], passengers, os.Getenv("PAYMENT_TOKEN"))
+		if err != nil {
+			log.Fatalf("could not purchase ticket: %s", describeError(err))
+		}
+		fmt.Printf("Purchase Response: %s\n", resp.ReceiptId)
+
+	case "hold_seats":
+		if len(os.Args) < 5 {
+			log.Fatalf("Usage: %s hold_seats <departure_id> <email> <seat>...", os.Args[0])
+		}
+		resp, err := c.HoldSeats(ctx, os.Args[2], os.Args[3], os.Args[4:])
+		if err != nil {
+			log.Fatalf("could not hold seats: %s", describeError(err))
+		}
+		fmt.Printf("Hold %s: %s until %s\n", resp.Hold.HoldId, strings.Join(resp.Hold.Seats, " "), resp.Hold.ExpiresAt.AsTime().Local().Format(time.Kitchen))
+
+	case "purchase_held":
+		if len(os.Args) < 9 || (len(os.Args)-6)%3 != 0 {
+			log.Fatalf("Usage: %s purchase_held <hold_id> <from> <to> <departure_id> <first_name> <last_name> <email> [<first_name> <last_name> <email>]...", os.Args[0])
+		}
+		holdID := os.Args[2]
+		from := os.Args[3]
+		to := os.Args[4]
+		departureID := os.Args[5]
+
+		// Passengers take the held seats in order; the first books the ticket
+		passengers := parsePassengers(os.Args[6:])
+		resp, err := c.PurchaseHeldSeats(ctx, holdID, from, to, departureID, passengers[0], passengers, os.Getenv("PAYMENT_TOKEN"))
+		if err != nil {
+			log.Fatalf("could not purchase ticket: %s", describeError(err))
+		}
+		fmt.Printf("Purchase Response: %s\n", resp.ReceiptId)
+
+	case "release_hold":
+		if len(os.Args) < 4 {
+			log.Fatalf("Usage: %s release_hold <hold_id> <email>", os.Args[0])
+		}
+		resp, err := c.ReleaseHold(ctx, os.Args[2], os.Args[3])
+		if err != nil {
+			log.Fatalf("could not release hold: %s", describeError(err))
+		}
+		if resp.Success {
+			fmt.Println("Hold released successfully.")
+		} else {
+			fmt.Println("Failed to release hold.")
+		}
+
+	case "join_waitlist":
+		if len(os.Args) < 6 {
+			log.Fatalf("Usage: %s join_waitlist <departure_id> <first_name> <last_name> <email> [section|-] [priority]", os.Args[0])
+		}
+		user := &pb.User{FirstName: os.Args[3], LastName: os.Args[4], Email: os.Args[5]}
+		section := ""
+		if len(os.Args) > 6 && os.Args[6] != "-" {
+			section = os.Args[6]
+		}
+		var priority int64
+		if len(os.Args) > 7 {
+			if priority, err = strconv.ParseInt(os.Args[7], 10, 32); err != nil {
+				log.Fatalf("invalid priority %q", os.Args[7])
+			}
+		}
+		resp, err := c.JoinWaitlist(ctx, os.Args[2], section, user, int32(priority))
+		if err != nil {
+			log.Fatalf("could not join waitlist: %s", describeError(err))
+		}
+		fmt.Println(describeWaitlistEntry(resp.Entry))
+
+	case "watch_waitlist":
+		if len(os.Args) < 4 {
+			log.Fatalf("Usage: %s watch_waitlist <entry_id> <email>", os.Args[0])
+		}
+		// Waiting for a seat can take much longer than a single call
+		err := c.WatchWaitlist(context.Background(), os.Args[2], os.Args[3], func(entry *pb.WaitlistEntry) {
+			fmt.Println(describeWaitlistEntry(entry))
+		})
+		if err != nil {
+			log.Fatalf("could not watch waitlist: %s", describeError(err))
+		}
+
+	case "watch_seats":
 		if len(os.Args) < 3 {
-			log.Fatalf("Usage: %s view_users <section>", os.Args[0])
+			log.Fatalf("Usage: %s watch_seats <departure_id>", os.Args[0])
 		}
-		section := os.Args[2]
-		resp, err := c.ViewUsersBySection(ctx, section)
+		// Watch until interrupted
+		err := c.WatchSeatMap(context.Background(), os.Args[2], func(event *pb.SeatMapEvent) {
+			fmt.Println(describeSeatMapEvent(event))
+		})
 		if err != nil {
-			log.Fatalf("could not view users: %v", err)
+			log.Fatalf("could not watch seats: %s", describeError(err))
+		}
+
+	case "select_seats":
+		if len(os.Args) < 6 {
+			log.Fatalf("Usage: %s select_seats <from> <to> <departure_id> <email>", os.Args[0])
+		}
+		from, to, paymentToken := os.Args[2], os.Args[3], os.Getenv("PAYMENT_TOKEN")
+		fmt.Println("Type pick <seat>, unpick <seat> or confirm <first_name> <last_name> <email>..., end with Ctrl-D")
+		actions := make(chan *pb.SeatSelectionRequest)
+		go func() {
+			defer close(actions)
+			lines := bufio.NewScanner(os.Stdin)
+			for lines.Scan() {
+				if strings.TrimSpace(lines.Text()) == "" {
+					continue
+				}
+				action, err := parseSelectionAction(lines.Text(), from, to, paymentToken)
+				if err != nil {
+					fmt.Fprintln(os.Stderr, err)
+					continue
+				}
+				actions <- action
+			}
+		}()
+		// Choosing seats takes as long as it takes
+		err := c.SeatSelectionSession(context.Background(), os.Args[4], os.Args[5], actions, func(event *pb.SeatSelectionEvent) {
+			fmt.Println(describeSelectionEvent(event))
+		})
+		if err != nil {
+			log.Fatalf("seat selection failed: %s", describeError(err))
+		}
+
+	case "leave_waitlist":
+		if len(os.Args) < 4 {
+			log.Fatalf("Usage: %s leave_waitlist <entry_id> <email>", os.Args[0])
+		}
+		resp, err := c.LeaveWaitlist(ctx, os.Args[2], os.Args[3])
+		if err != nil {
+			log.Fatalf("could not leave waitlist: %s", describeError(err))
+		}
+		if resp.Success {
+			fmt.Println("Left the waitlist.")
+		} else {
+			fmt.Println("Failed to leave the waitlist.")
+		}
+
+	case "boarding_pass":
+		if len(os.Args) < 3 {
+			log.Fatalf("Usage: %s boarding_pass <receipt_id> [seat|-] [png_file]", os.Args[0])
+		}
+		seat := ""
+		if len(os.Args) > 3 && os.Args[3] != "-" {
+			seat = os.Args[3]
+		}
+		pass, err := c.GetBoardingPass(ctx, os.Args[2], seat)
+		if err != nil {
+			log.Fatalf("could not get boarding pass: %s", describeError(err))
+		}
+		fmt.Println(describeBoardingPass(pass))
+		fmt.Print(pass.QrText)
+		fmt.Println(pass.Token)
+		if len(os.Args) > 4 {
+			if err := os.WriteFile(os.Args[4], pass.QrPng, 0644); err != nil {
+				log.Fatalf("could not save QR code: %v", err)
+			}
+		}
+
+	case "verify_pass":
+		// Checked offline: the server is never asked
+		if len(os.Args) < 4 {
+			log.Fatalf("Usage: %s verify_pass <public_key> <token>", os.Args[0])
+		}
+		key, err := boardingpass.DecodePublicKey(os.Args[2])
+		if err != nil {
+			log.Fatal(err)
+		}
+		p, err := boardingpass.Verify(key, os.Args[3])
+		if err != nil {
+			log.Fatalf("invalid boarding pass: %v", err)
+		}
+		fmt.Printf("Valid boarding pass: %s seat %s on %s (%s)\n", p.Passenger, p.Seat, p.DepartureID, p.ReceiptID)
+
+	case "validate_ticket":
+		if len(os.Args) < 4 {
+			log.Fatalf("Usage: %s validate_ticket <departure_id> <token>", os.Args[0])
+		}
+		resp, err := c.ValidateTicket(ctx, os.Args[2], os.Args[3])
+		if err != nil {
+			log.Fatalf("ticket rejected: %s", describeError(err))
+		}
+		fmt.Printf("Checked in %s, %s %s (%s) at %s\n", resp.Passenger.GetEmail(), resp.Section, resp.Seat, resp.ReceiptId,
+			resp.CheckedInAt.AsTime().Local().Format(time.Kitchen))
+
+	case "get_receipt":
+		usage := fmt.Sprintf("Usage: %s get_receipt <receipt_id> [--format text|html|pdf] [-o file]", os.Args[0])
+		fs := flag.NewFlagSet("get_receipt", flag.ExitOnError)
+		formatName := fs.String("format", "text", "receipt format: text, html or pdf")
+		output := fs.String("o", "", "file to write the receipt to (default: standard output)")
+		args := parseArgs(fs, os.Args[2:], 1)
+		if args == nil {
+			log.Fatal(usage)
+		}
+		receiptId := args[0]
+		format, err := parseReceiptFormat(*formatName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		resp, err := c.RenderReceipt(ctx, receiptId, format)
+		if err != nil {
+			log.Fatalf("could not get receipt: %s", describeError(err))
+		}
+		if *output == "" {
+			os.Stdout.Write(resp.Content)
+		} else if err := os.WriteFile(*output, resp.Content, 0644); err != nil {
+			log.Fatalf("could not save receipt: %v", err)
+		}
+
+	case "view_users":
+		usage := fmt.Sprintf("Usage: %s view_users <section> [--departure id] [--route id] [--sort seat|name] [--filter expr]", os.Args[0])
+		fs := flag.NewFlagSet("view_users", flag.ExitOnError)
+		departureID := fs.String("departure", "", "only list passengers on this departure")
+		routeID := fs.String("route", "", "only list passengers on this route")
+		sortBy := fs.String("sort", "seat", "order passengers by seat or name")
+		filter := fs.String("filter", "", `only list matching passengers, e.g. "domain = example.com AND seat >= A5"`)
+		args := parseArgs(fs, os.Args[2:], 1)
+		if args == nil {
+			log.Fatal(usage)
+		}
+		section := args[0]
+		order, err := parseManifestOrder(*sortBy)
+		if err != nil {
+			log.Fatal(err)
+		}
+		resp, err := c.ViewUsersBySection(ctx, section, *departureID, *routeID, order, *filter)
+		if err != nil {
+			log.Fatalf("could not view users: %s", describeError(err))
+		}
+		fmt.Printf("Users in %s:\n", section)
+		for _, us := range resp.UserSeats {
+			fmt.Println(describeUserSeat(us))
 		}
-		fmt.Printf("Users in %s: %+v\n", section, resp.UserSeats)
 
 	case "remove_user":
 		if len(os.Args) < 3 {
@@ -123,23 +1011,30 @@ func main() {
 		email := os.Args[2]
 		resp, err := c.RemoveUser(ctx, email)
 		if err != nil {
-			log.Fatalf("could not remove user: %v", err)
+			log.Fatalf("could not remove user: %s", describeError(err))
 		}
 		if resp.Success {
 			fmt.Println("User removed successfully.")
 		} else {
 			fmt.Println("Failed to remove user.")
 		}
+		for _, refund := range resp.Refunds {
+			fmt.Println(describeRefund(refund))
+		}
 
 	case "modify_seat":
 		if len(os.Args) < 4 {
-			log.Fatalf("Usage: %s modify_seat <email> <new_seat>", os.Args[0])
+			log.Fatalf("Usage: %s modify_seat <receipt_id> <new_seat> [current_seat]", os.Args[0])
 		}
-		email := os.Args[2]
+		receiptID := os.Args[2]
 		newSeat := os.Args[3]
-		resp, err := c.ModifySeat(ctx, email, newSeat)
+		var currentSeat string
+		if len(os.Args) > 4 {
+			currentSeat = os.Args[4]
+		}
+		resp, err := c.ModifySeat(ctx, receiptID, currentSeat, newSeat)
 		if err != nil {
-			log.Fatalf("could not modify seat: %v", err)
+			log.Fatalf("could not modify seat: %s", describeError(err))
 		}
 		if resp.Success {
 			fmt.Println("Seat modified successfully.")
@@ -147,6 +1042,67 @@ func main() {
 			fmt.Println("Failed to modify seat.")
 		}
 
+	case "cancel_ticket":
+		if len(os.Args) < 3 {
+			log.Fatalf("Usage: %s cancel_ticket <receipt_id>", os.Args[0])
+		}
+		resp, err := c.CancelTicket(ctx, os.Args[2])
+		if err != nil {
+			log.Fatalf("could not cancel ticket: %s", describeError(err))
+		}
+		if resp.Success {
+			fmt.Println("Ticket cancelled successfully.")
+		} else {
+			fmt.Println("Failed to cancel ticket.")
+		}
+		if resp.Refund != nil {
+			fmt.Println(describeRefund(resp.Refund))
+		}
+
+	case "my_tickets":
+		fs := flag.NewFlagSet("my_tickets", flag.ExitOnError)
+		filter := fs.String("filter", "", `only list matching tickets, e.g. "status = confirmed"`)
+		args := parseArgs(fs, os.Args[2:], 1)
+		if args == nil {
+			log.Fatalf("Usage: %s my_tickets <email> [--filter expr]", os.Args[0])
+		}
+		resp, err := c.ListMyTickets(ctx, args[0], *filter)
+		if err != nil {
+			log.Fatalf("could not list tickets: %s", describeError(err))
+		}
+		for _, receipt := range resp.Receipts {
+			fmt.Printf("%s  %s  %s -> %s  %s %s  %s  %s\n", receipt.ReceiptId, receipt.DepartureId, receipt.From, receipt.To, receipt.Section, receipt.Seat, money.Format(receipt.PricePaid), bookingStatus(receipt.Status))
+		}
+
+	case "list_stations":
+		fs := flag.NewFlagSet("list_stations", flag.ExitOnError)
+		filter := fs.String("filter", "", `only list matching stations, e.g. "name : lon"`)
+		fs.Parse(os.Args[2:])
+		resp, err := c.ListStations(ctx, *filter)
+		if err != nil {
+			log.Fatalf("could not list stations: %s", describeError(err))
+		}
+		for _, st := range resp.Stations {
+			fmt.Printf("%-5s %s\n", st.Id, st.Name)
+		}
+
+	case "list_departures":
+		fs := flag.NewFlagSet("list_departures", flag.ExitOnError)
+		filter := fs.String("filter", "", `only list matching departures, e.g. "train = ES9024"`)
+		args := parseArgs(fs, os.Args[2:], 3)
+		if args == nil {
+			log.Fatalf("Usage: %s list_departures <from> <to> <date YYYY-MM-DD> [--filter expr]", os.Args[0])
+		}
+		resp, err := c.ListDepartures(ctx, args[0], args[1], args[2], *filter)
+		if err != nil {
+			log.Fatalf("could not list departures: %s", describeError(err))
+		}
+		for _, dep := range resp.Departures {
+			fmt.Printf("%s  %s  %s %s -> %s %s\n", dep.Id, dep.TrainName,
+				dep.From.Name, dep.DepartureTime.AsTime().Format("15:04"),
+				dep.To.Name, dep.ArrivalTime.AsTime().Format("15:04"))
+		}
+
 	default:
 		log.Fatalf("Unknown command: %s", command)
 	}
@@ -2,12 +2,21 @@ package main
 
 import (
 	"context"
+	"flag"
+	"io"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // MockTicketServiceClient is a mock implementation of TicketServiceClient
@@ -40,6 +49,161 @@ func (m *MockTicketServiceClient) ModifySeat(ctx context.Context, in *pb.ModifyS
 	return args.Get(0).(*pb.ModifySeatResponse), args.Error(1)
 }
 
+func (m *MockTicketServiceClient) ListStations(ctx context.Context, in *pb.ListStationsRequest, opts ...grpc.CallOption) (*pb.ListStationsResponse, error) {
+	args := m.Called(ctx, in)
+	return args.Get(0).(*pb.ListStationsResponse), args.Error(1)
+}
+
+func (m *MockTicketServiceClient) ListDepartures(ctx context.Context, in *pb.ListDeparturesRequest, opts ...grpc.CallOption) (*pb.ListDeparturesResponse, error) {
+	args := m.Called(ctx, in)
+	return args.Get(0).(*pb.ListDeparturesResponse), args.Error(1)
+}
+
+func (m *MockTicketServiceClient) CancelTicket(ctx context.Context, in *pb.CancelTicketRequest, opts ...grpc.CallOption) (*pb.CancelTicketResponse, error) {
+	args := m.Called(ctx, in)
+	return args.Get(0).(*pb.CancelTicketResponse), args.Error(1)
+}
+
+func (m *MockTicketServiceClient) ListMyTickets(ctx context.Context, in *pb.ListMyTicketsRequest, opts ...grpc.CallOption) (*pb.ListMyTicketsResponse, error) {
+	args := m.Called(ctx, in)
+	return args.Get(0).(*pb.ListMyTicketsResponse), args.Error(1)
+}
+
+func (m *MockTicketServiceClient) HoldSeats(ctx context.Context, in *pb.HoldSeatsRequest, opts ...grpc.CallOption) (*pb.HoldSeatsResponse, error) {
+	args := m.Called(ctx, in)
+	return args.Get(0).(*pb.HoldSeatsResponse), args.Error(1)
+}
+
+func (m *MockTicketServiceClient) ReleaseHold(ctx context.Context, in *pb.ReleaseHoldRequest, opts ...grpc.CallOption) (*pb.ReleaseHoldResponse, error) {
+	args := m.Called(ctx, in)
+	return args.Get(0).(*pb.ReleaseHoldResponse), args.Error(1)
+}
+
+func (m *MockTicketServiceClient) JoinWaitlist(ctx context.Context, in *pb.JoinWaitlistRequest, opts ...grpc.CallOption) (*pb.JoinWaitlistResponse, error) {
+	args := m.Called(ctx, in)
+	return args.Get(0).(*pb.JoinWaitlistResponse), args.Error(1)
+}
+
+func (m *MockTicketServiceClient) LeaveWaitlist(ctx context.Context, in *pb.LeaveWaitlistRequest, opts ...grpc.CallOption) (*pb.LeaveWaitlistResponse, error) {
+	args := m.Called(ctx, in)
+	return args.Get(0).(*pb.LeaveWaitlistResponse), args.Error(1)
+}
+
+func (m *MockTicketServiceClient) WatchWaitlist(ctx context.Context, in *pb.WatchWaitlistRequest, opts ...grpc.CallOption) (pb.TicketService_WatchWaitlistClient, error) {
+	args := m.Called(ctx, in)
+	return args.Get(0).(pb.TicketService_WatchWaitlistClient), args.Error(1)
+}
+
+func (m *MockTicketServiceClient) WatchSeatMap(ctx context.Context, in *pb.WatchSeatMapRequest, opts ...grpc.CallOption) (pb.TicketService_WatchSeatMapClient, error) {
+	args := m.Called(ctx, in)
+	return args.Get(0).(pb.TicketService_WatchSeatMapClient), args.Error(1)
+}
+
+func (m *MockTicketServiceClient) BulkPurchase(ctx context.Context, opts ...grpc.CallOption) (pb.TicketService_BulkPurchaseClient, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(pb.TicketService_BulkPurchaseClient), args.Error(1)
+}
+
+func (m *MockTicketServiceClient) SeatSelectionSession(ctx context.Context, opts ...grpc.CallOption) (pb.TicketService_SeatSelectionSessionClient, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(pb.TicketService_SeatSelectionSessionClient), args.Error(1)
+}
+
+func (m *MockTicketServiceClient) GetBoardingPass(ctx context.Context, in *pb.GetBoardingPassRequest, opts ...grpc.CallOption) (*pb.BoardingPass, error) {
+	args := m.Called(ctx, in)
+	return args.Get(0).(*pb.BoardingPass), args.Error(1)
+}
+
+func (m *MockTicketServiceClient) ValidateTicket(ctx context.Context, in *pb.ValidateTicketRequest, opts ...grpc.CallOption) (*pb.ValidateTicketResponse, error) {
+	args := m.Called(ctx, in)
+	return args.Get(0).(*pb.ValidateTicketResponse), args.Error(1)
+}
+
+func (m *MockTicketServiceClient) RenderReceipt(ctx context.Context, in *pb.RenderReceiptRequest, opts ...grpc.CallOption) (*pb.RenderReceiptResponse, error) {
+	args := m.Called(ctx, in)
+	return args.Get(0).(*pb.RenderReceiptResponse), args.Error(1)
+}
+
+// mockWaitlistStream replays entries and then ends the stream
+type mockWaitlistStream struct {
+	grpc.ClientStream
+	entries []*pb.WaitlistEntry
+}
+
+func (m *mockWaitlistStream) Recv() (*pb.WaitlistEntry, error) {
+	if len(m.entries) == 0 {
+		return nil, io.EOF
+	}
+	entry := m.entries[0]
+	m.entries = m.entries[1:]
+	return entry, nil
+}
+
+// mockSeatMapStream replays events and then fails with err, or ends the
+// stream if err is nil
+type mockSeatMapStream struct {
+	grpc.ClientStream
+	events []*pb.SeatMapEvent
+	err    error
+}
+
+func (m *mockSeatMapStream) Recv() (*pb.SeatMapEvent, error) {
+	if len(m.events) == 0 {
+		if m.err != nil {
+			return nil, m.err
+		}
+		return nil, io.EOF
+	}
+	event := m.events[0]
+	m.events = m.events[1:]
+	return event, nil
+}
+
+// mockBulkPurchaseStream records the purchases sent and replies with resp
+type mockBulkPurchaseStream struct {
+	grpc.ClientStream
+	sent []*pb.PurchaseRequest
+	resp *pb.BulkPurchaseResponse
+}
+
+func (m *mockBulkPurchaseStream) Send(req *pb.PurchaseRequest) error {
+	m.sent = append(m.sent, req)
+	return nil
+}
+
+func (m *mockBulkPurchaseStream) CloseAndRecv() (*pb.BulkPurchaseResponse, error) {
+	return m.resp, nil
+}
+
+// mockSelectionStream records the actions sent and replays events, ending
+// the session once the client stops sending.
+type mockSelectionStream struct {
+	grpc.ClientStream
+	sent   []*pb.SeatSelectionRequest
+	events []*pb.SeatSelectionEvent
+	closed chan struct{}
+}
+
+func (m *mockSelectionStream) Send(req *pb.SeatSelectionRequest) error {
+	m.sent = append(m.sent, req)
+	return nil
+}
+
+func (m *mockSelectionStream) CloseSend() error {
+	close(m.closed)
+	return nil
+}
+
+func (m *mockSelectionStream) Recv() (*pb.SeatSelectionEvent, error) {
+	if len(m.events) == 0 {
+		<-m.closed
+		return nil, io.EOF
+	}
+	event := m.events[0]
+	m.events = m.events[1:]
+	return event, nil
+}
+
 // TestPurchaseTicketClient tests the PurchaseTicket function
 func TestPurchaseTicketClient(t *testing.T) {
 	// Create a new instance of MockTicketServiceClient
@@ -61,10 +225,11 @@ func TestPurchaseTicketClient(t *testing.T) {
 		Email:     "john.doe@example.com",
 	}
 	from := "London"
-	to := "France"
+	to := "Paris"
+	departureID := "ES9010-20300901"
 
 	// Call the PurchaseTicket method
-	resp, err := client.PurchaseTicket(context.Background(), from, to, user)
+	resp, err := client.PurchaseTicket(context.Background(), from, to, departureID, user, "", "", "", "tok_visa")
 
 	// Assert no error occurred
 	assert.NoError(t, err)
@@ -86,7 +251,7 @@ func TestGetReceipt(t *testing.T) {
 		From:      "London",
 		To:        "France",
 		User:      &pb.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
-		PricePaid: 20,
+		PricePaid: &pb.Money{CurrencyCode: "GBP", Units: 20},
 		Seat:      "Seat-1",
 	}
 
@@ -124,18 +289,445 @@ func TestRemoveUser(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+// TestPurchaseGroupTicket tests the PurchaseGroupTicket method of the client
+func TestPurchaseGroupTicket(t *testing.T) {
+	mockClient := new(MockTicketServiceClient)
+	expectedResponse := &pb.PurchaseResponse{ReceiptId: "rec-7"}
+	booker := &pb.User{FirstName: "Jane", LastName: "Doe", Email: "jane.doe@example.com"}
+	passengers := []*pb.User{booker, {FirstName: "Kid", LastName: "Doe", Email: "kid.doe@example.com"}}
+
+	mockClient.On("PurchaseTicket", mock.Anything, &pb.PurchaseRequest{
+		From:        "London",
+		To:          "Paris",
+		DepartureId: "ES9010-20300901",
+		User:        booker,
+		Passengers:  passengers,
+	}).Return(expectedResponse, nil)
+
+	client := &Client{client: mockClient}
+	resp, err := client.PurchaseGroupTicket(context.Background(), "London", "Paris", "ES9010-20300901", booker, passengers, "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "rec-7", resp.ReceiptId)
+	mockClient.AssertExpectations(t)
+}
+
 // TestModifySeat tests the ModifySeat method of the client
 func TestModifySeat(t *testing.T) {
 	mockClient := new(MockTicketServiceClient)
 	expectedResponse := &pb.ModifySeatResponse{Success: true}
 
-	mockClient.On("ModifySeat", mock.Anything, &pb.ModifySeatRequest{Email: "alice.smith@example.com", NewSeat: "Seat-42"}).
+	mockClient.On("ModifySeat", mock.Anything, &pb.ModifySeatRequest{ReceiptId: "rec-1", CurrentSeat: "A2", NewSeat: "B7"}).
 		Return(expectedResponse, nil)
 
 	client := &Client{client: mockClient}
-	resp, err := client.ModifySeat(context.Background(), "alice.smith@example.com", "Seat-42")
+	resp, err := client.ModifySeat(context.Background(), "rec-1", "A2", "B7")
 
 	assert.NoError(t, err)
 	assert.True(t, resp.Success)
 	mockClient.AssertExpectations(t)
 }
+
+// TestListDepartures tests the ListDepartures method of the client
+func TestListDepartures(t *testing.T) {
+	mockClient := new(MockTicketServiceClient)
+	expectedResponse := &pb.ListDeparturesResponse{
+		Departures: []*pb.Departure{{Id: "ES9010-20300901", TrainId: "ES9010"}},
+	}
+
+	mockClient.On("ListDepartures", mock.Anything, &pb.ListDeparturesRequest{From: "London", To: "Paris", Date: "2030-09-01"}).
+		Return(expectedResponse, nil)
+
+	client := &Client{client: mockClient}
+	resp, err := client.ListDepartures(context.Background(), "London", "Paris", "2030-09-01", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResponse, resp)
+	mockClient.AssertExpectations(t)
+}
+
+// TestDescribeError tests that status details are rendered for the user
+func TestDescribeError(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "unknown station \"Atlantis\"").WithDetails(
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: "from", Description: "unknown station \"Atlantis\""},
+		}},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "InvalidArgument: unknown station \"Atlantis\"\n  invalid from: unknown station \"Atlantis\"", describeError(st.Err()))
+
+	st, err = status.New(codes.NotFound, "receipt \"rec-9\" not found").WithDetails(
+		&errdetails.ErrorInfo{Reason: "RECEIPT_NOT_FOUND", Domain: "TicketService", Metadata: map[string]string{"receipt_id": "rec-9"}},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, "NotFound: receipt \"rec-9\" not found\n  reason: RECEIPT_NOT_FOUND\n  receipt_id: rec-9", describeError(st.Err()))
+}
+
+// TestCancelTicket tests the CancelTicket method of the client
+func TestCancelTicket(t *testing.T) {
+	mockClient := new(MockTicketServiceClient)
+	expectedResponse := &pb.CancelTicketResponse{Success: true}
+
+	mockClient.On("CancelTicket", mock.Anything, &pb.CancelTicketRequest{ReceiptId: "rec-1"}).
+		Return(expectedResponse, nil)
+
+	client := &Client{client: mockClient}
+	resp, err := client.CancelTicket(context.Background(), "rec-1")
+
+	assert.NoError(t, err)
+	assert.True(t, resp.Success)
+	mockClient.AssertExpectations(t)
+}
+
+// TestListMyTickets tests the ListMyTickets method of the client
+func TestListMyTickets(t *testing.T) {
+	mockClient := new(MockTicketServiceClient)
+	expectedResponse := &pb.ListMyTicketsResponse{Receipts: []*pb.ReceiptResponse{
+		{ReceiptId: "rec-1", DepartureId: "ES9010-20300901"},
+		{ReceiptId: "rec-2", DepartureId: "ES9007-20300905"},
+	}}
+
+	mockClient.On("ListMyTickets", mock.Anything, &pb.ListMyTicketsRequest{Email: "alice.smith@example.com"}).
+		Return(expectedResponse, nil)
+
+	client := &Client{client: mockClient}
+	resp, err := client.ListMyTickets(context.Background(), "alice.smith@example.com", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedResponse, resp)
+	mockClient.AssertExpectations(t)
+}
+
+func TestDescribeRefund(t *testing.T) {
+	refund := &pb.Refund{
+		RefundId:  "rec-1-refund-1",
+		ReceiptId: "rec-1",
+		Amount:    &pb.Money{CurrencyCode: "EUR", Units: 31, Nanos: 500_000_000},
+		Percent:   50,
+		Rule:      "partial",
+	}
+	assert.Equal(t, "Refund rec-1-refund-1 for rec-1: €31.50 (50%, partial)", describeRefund(refund))
+	assert.Equal(t, "cancelled", bookingStatus(pb.BookingStatus_BOOKING_STATUS_CANCELLED))
+	assert.Equal(t, "confirmed", bookingStatus(pb.BookingStatus_BOOKING_STATUS_UNSPECIFIED))
+}
+
+// TestHoldSeats tests holding seats and buying them with the client
+func TestHoldSeats(t *testing.T) {
+	mockClient := new(MockTicketServiceClient)
+	hold := &pb.Hold{HoldId: "hold-1", DepartureId: "ES9010-20300901", Seats: []string{"A1", "A2"}}
+	passengers := parsePassengers([]string{"Jane", "Doe", "jane.doe@example.com", "Kid", "Doe", "kid.doe@example.com"})
+	assert.Len(t, passengers, 2)
+
+	mockClient.On("HoldSeats", mock.Anything, &pb.HoldSeatsRequest{DepartureId: "ES9010-20300901", Seats: []string{"A1", "A2"}, Email: "jane.doe@example.com"}).
+		Return(&pb.HoldSeatsResponse{Hold: hold}, nil)
+	mockClient.On("PurchaseTicket", mock.Anything, &pb.PurchaseRequest{
+		From:        "London",
+		To:          "Paris",
+		DepartureId: "ES9010-20300901",
+		User:        passengers[0],
+		Passengers:  passengers,
+		HoldId:      "hold-1",
+	}).Return(&pb.PurchaseResponse{ReceiptId: "rec-1"}, nil)
+	mockClient.On("ReleaseHold", mock.Anything, &pb.ReleaseHoldRequest{HoldId: "hold-2", Email: "jane.doe@example.com"}).
+		Return(&pb.ReleaseHoldResponse{Success: true}, nil)
+
+	client := &Client{client: mockClient}
+	holdResp, err := client.HoldSeats(context.Background(), "ES9010-20300901", "jane.doe@example.com", []string{"A1", "A2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "hold-1", holdResp.Hold.HoldId)
+	purchaseResp, err := client.PurchaseHeldSeats(context.Background(), "hold-1", "London", "Paris", "ES9010-20300901", passengers[0], passengers, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "rec-1", purchaseResp.ReceiptId)
+	releaseResp, err := client.ReleaseHold(context.Background(), "hold-2", "jane.doe@example.com")
+	assert.NoError(t, err)
+	assert.True(t, releaseResp.Success)
+	mockClient.AssertExpectations(t)
+}
+
+// TestWaitlist tests joining, watching and leaving a waitlist with the client
+func TestWaitlist(t *testing.T) {
+	mockClient := new(MockTicketServiceClient)
+	user := &pb.User{FirstName: "Ann", LastName: "Lee", Email: "ann@example.com"}
+	waiting := &pb.WaitlistEntry{EntryId: "wait-1", DepartureId: "ES9010-20300901", Status: pb.WaitlistStatus_WAITLIST_STATUS_WAITING, Position: 2}
+	promoted := &pb.WaitlistEntry{EntryId: "wait-1", DepartureId: "ES9010-20300901", Status: pb.WaitlistStatus_WAITLIST_STATUS_PROMOTED,
+		Hold: &pb.Hold{HoldId: "hold-1", Seats: []string{"B1"}}}
+
+	mockClient.On("JoinWaitlist", mock.Anything, &pb.JoinWaitlistRequest{DepartureId: "ES9010-20300901", Section: "SectionB", User: user, Priority: 1}).
+		Return(&pb.JoinWaitlistResponse{Entry: waiting}, nil)
+	mockClient.On("WatchWaitlist", mock.Anything, &pb.WatchWaitlistRequest{EntryId: "wait-1", Email: "ann@example.com"}).
+		Return(&mockWaitlistStream{entries: []*pb.WaitlistEntry{waiting, promoted}}, nil)
+	mockClient.On("LeaveWaitlist", mock.Anything, &pb.LeaveWaitlistRequest{EntryId: "wait-1", Email: "ann@example.com"}).
+		Return(&pb.LeaveWaitlistResponse{Success: true}, nil)
+
+	client := &Client{client: mockClient}
+	joinResp, err := client.JoinWaitlist(context.Background(), "ES9010-20300901", "SectionB", user, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "wait-1: waiting at position 2 for ES9010-20300901", describeWaitlistEntry(joinResp.Entry))
+
+	var seen []*pb.WaitlistEntry
+	err = client.WatchWaitlist(context.Background(), "wait-1", "ann@example.com", func(entry *pb.WaitlistEntry) { seen = append(seen, entry) })
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.WaitlistEntry{waiting, promoted}, seen)
+
+	leaveResp, err := client.LeaveWaitlist(context.Background(), "wait-1", "ann@example.com")
+	assert.NoError(t, err)
+	assert.True(t, leaveResp.Success)
+	mockClient.AssertExpectations(t)
+}
+
+// TestGetBoardingPass tests fetching a boarding pass with the client
+func TestGetBoardingPass(t *testing.T) {
+	mockClient := new(MockTicketServiceClient)
+	boards := time.Date(2030, 9, 1, 8, 1, 0, 0, time.Local)
+	pass := &pb.BoardingPass{
+		ReceiptId: "rec-1",
+		Passenger: &pb.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
+		Section:   "SectionA",
+		Seat:      "A1",
+		From:      &pb.Station{Name: "London"},
+		To:        &pb.Station{Name: "Paris"},
+		BoardsAt:  timestamppb.New(boards),
+		Token:     "BP1.payload.signature",
+	}
+	mockClient.On("GetBoardingPass", mock.Anything, &pb.GetBoardingPassRequest{ReceiptId: "rec-1"}).Return(pass, nil)
+
+	client := &Client{client: mockClient}
+	resp, err := client.GetBoardingPass(context.Background(), "rec-1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "BP1.payload.signature", resp.Token)
+	assert.Equal(t, "John Doe  London -> Paris  departs 2030-09-01 08:01  SectionA A1  (rec-1)", describeBoardingPass(resp))
+	mockClient.AssertExpectations(t)
+}
+
+// TestValidateTicket tests checking a passenger in with the client
+func TestValidateTicket(t *testing.T) {
+	mockClient := new(MockTicketServiceClient)
+	mockClient.On("ValidateTicket", mock.Anything, &pb.ValidateTicketRequest{DepartureId: "ES9010-20300901", Token: "BP1.payload.signature"}).
+		Return(&pb.ValidateTicketResponse{ReceiptId: "rec-1", Seat: "A1"}, nil).Once()
+	mockClient.On("ValidateTicket", mock.Anything, &pb.ValidateTicketRequest{DepartureId: "ES9010-20300901", Token: "BP1.payload.signature"}).
+		Return((*pb.ValidateTicketResponse)(nil), status.Error(codes.FailedPrecondition, "john.doe@example.com already boarded")).Once()
+
+	client := &Client{client: mockClient}
+	resp, err := client.ValidateTicket(context.Background(), "ES9010-20300901", "BP1.payload.signature")
+	assert.NoError(t, err)
+	assert.Equal(t, "A1", resp.Seat)
+	_, err = client.ValidateTicket(context.Background(), "ES9010-20300901", "BP1.payload.signature")
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	mockClient.AssertExpectations(t)
+}
+
+// TestRenderReceipt tests fetching a printable receipt with the client
+func TestRenderReceipt(t *testing.T) {
+	mockClient := new(MockTicketServiceClient)
+	mockClient.On("RenderReceipt", mock.Anything, &pb.RenderReceiptRequest{ReceiptId: "rec-1", Format: pb.ReceiptFormat_RECEIPT_FORMAT_PDF}).
+		Return(&pb.RenderReceiptResponse{Content: []byte("%PDF-1.3"), ContentType: "application/pdf", Filename: "rec-1.pdf"}, nil)
+
+	format, err := parseReceiptFormat("pdf")
+	assert.NoError(t, err)
+	client := &Client{client: mockClient}
+	resp, err := client.RenderReceipt(context.Background(), "rec-1", format)
+	assert.NoError(t, err)
+	assert.Equal(t, "rec-1.pdf", resp.Filename)
+	mockClient.AssertExpectations(t)
+
+	_, err = parseReceiptFormat("docx")
+	assert.Error(t, err)
+	_, err = parseReceiptFormat("unspecified")
+	assert.Error(t, err)
+}
+
+// TestViewUsersBySection tests fetching a sorted, filtered manifest with the client
+func TestViewUsersBySection(t *testing.T) {
+	mockClient := new(MockTicketServiceClient)
+	userSeat := &pb.UserSeat{
+		User:        &pb.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
+		Seat:        "A1",
+		ReceiptId:   "rec-1",
+		DepartureId: "ES9010-20300901",
+		Section:     "SectionA",
+		RouteId:     "LON-PAR",
+		From:        &pb.Station{Name: "London"},
+		To:          &pb.Station{Name: "Paris"},
+	}
+	mockClient.On("ViewUsersBySection", mock.Anything, &pb.ViewUsersRequest{Section: "SectionA", DepartureId: "ES9010-20300901", OrderBy: pb.ManifestOrder_MANIFEST_ORDER_NAME}).
+		Return(&pb.ViewUsersResponse{UserSeats: []*pb.UserSeat{userSeat}}, nil)
+
+	order, err := parseManifestOrder("name")
+	assert.NoError(t, err)
+	client := &Client{client: mockClient}
+	resp, err := client.ViewUsersBySection(context.Background(), "SectionA", "ES9010-20300901", "", order, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.UserSeat{userSeat}, resp.UserSeats)
+	line := describeUserSeat(userSeat)
+	for _, want := range []string{"A1", "John Doe", "john.doe@example.com", "rec-1", "London -> Paris"} {
+		assert.Contains(t, line, want)
+	}
+	mockClient.AssertExpectations(t)
+
+	_, err = parseManifestOrder("age")
+	assert.Error(t, err)
+}
+
+// TestListStationsPages tests that the client follows page tokens to the end
+func TestListStationsPages(t *testing.T) {
+	mockClient := new(MockTicketServiceClient)
+	mockClient.On("ListStations", mock.Anything, &pb.ListStationsRequest{Filter: "name : a"}).
+		Return(&pb.ListStationsResponse{Stations: []*pb.Station{{Id: "ASH"}}, NextPageToken: "page-2", TotalSize: 2}, nil)
+	mockClient.On("ListStations", mock.Anything, &pb.ListStationsRequest{Filter: "name : a", PageToken: "page-2"}).
+		Return(&pb.ListStationsResponse{Stations: []*pb.Station{{Id: "PAR"}}, TotalSize: 2}, nil)
+
+	client := &Client{client: mockClient}
+	resp, err := client.ListStations(context.Background(), "name : a")
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.Station{{Id: "ASH"}, {Id: "PAR"}}, resp.Stations)
+	assert.Equal(t, int32(2), resp.TotalSize)
+	mockClient.AssertExpectations(t)
+}
+
+// TestParseArgs tests that flags may come before or after positional arguments
+func TestParseArgs(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	filter := fs.String("filter", "", "")
+	args := parseArgs(fs, []string{"--filter", "route = LON-PAR", "London", "Paris", "2030-09-01"}, 3)
+	assert.Equal(t, []string{"London", "Paris", "2030-09-01"}, args)
+	assert.Equal(t, "route = LON-PAR", *filter)
+	args = parseArgs(fs, []string{"London", "Paris", "2030-09-01", "--filter", "train = ES9024"}, 3)
+	assert.Equal(t, []string{"London", "Paris", "2030-09-01"}, args)
+	assert.Equal(t, "train = ES9024", *filter)
+	assert.Nil(t, parseArgs(fs, []string{"London", "Paris"}, 3))
+}
+
+// TestWatchSeatMap tests that the client resumes a dropped seat map watch
+func TestWatchSeatMap(t *testing.T) {
+	seatMapRetryDelay = 0
+	mockClient := new(MockTicketServiceClient)
+	at := time.Date(2030, 9, 1, 8, 0, 0, 0, time.Local)
+	snapshot := &pb.SeatMapEvent{Snapshot: true, ResumeToken: "t0", Time: timestamppb.New(at), Seats: []*pb.SeatStatus{
+		{Seat: "A1", State: pb.SeatState_SEAT_STATE_FREE},
+		{Seat: "A2", State: pb.SeatState_SEAT_STATE_BOOKED},
+	}}
+	change := &pb.SeatMapEvent{ResumeToken: "t1", Time: timestamppb.New(at), Seats: []*pb.SeatStatus{{Seat: "A1", State: pb.SeatState_SEAT_STATE_HELD}}}
+	mockClient.On("WatchSeatMap", mock.Anything, &pb.WatchSeatMapRequest{DepartureId: "ES9010-20300901"}).
+		Return(&mockSeatMapStream{events: []*pb.SeatMapEvent{snapshot}, err: status.Error(codes.Unavailable, "connection reset")}, nil).Once()
+	mockClient.On("WatchSeatMap", mock.Anything, &pb.WatchSeatMapRequest{DepartureId: "ES9010-20300901", ResumeToken: "t0"}).
+		Return(&mockSeatMapStream{events: []*pb.SeatMapEvent{change}}, nil).Once()
+
+	client := &Client{client: mockClient}
+	var seen []string
+	err := client.WatchSeatMap(context.Background(), "ES9010-20300901", func(event *pb.SeatMapEvent) {
+		seen = append(seen, describeSeatMapEvent(event))
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"08:00:00 seats: A1 free, A2 booked", "08:00:00 changed: A1 held"}, seen)
+	mockClient.AssertExpectations(t)
+}
+
+// TestImportBookings tests reading a bookings file and streaming it to BulkPurchase
+func TestImportBookings(t *testing.T) {
+	bookings := `email,first_name,last_name,from,to,departure_id,seat,passenger_type,payment_token
+ann@example.com,Ann,Lee,London,Paris,ES9010-20300901,A1,,
+kid@example.com,Kid,Lee,London,Paris,ES9010-20300901,,child,tok_decline
+`
+	reqs, lines, err := parseBookingsCSV(strings.NewReader(bookings), "tok_visa")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, lines)
+	assert.Equal(t, &pb.PurchaseRequest{
+		From:          "London",
+		To:            "Paris",
+		DepartureId:   "ES9010-20300901",
+		User:          &pb.User{FirstName: "Ann", LastName: "Lee", Email: "ann@example.com"},
+		PreferredSeat: "A1",
+		PaymentToken:  "tok_visa",
+	}, reqs[0])
+	assert.Equal(t, pb.PassengerType_PASSENGER_TYPE_CHILD, reqs[1].User.PassengerType)
+	assert.Equal(t, "tok_decline", reqs[1].PaymentToken)
+
+	for _, bad := range []string{
+		"email,from,to\n",
+		"email,first_name,last_name,from,to,departure_id,age\n",
+		"email,first_name,last_name,from,to,departure_id,passenger_type\na@example.com,A,B,London,Paris,ES9010-20300901,infant\n",
+	} {
+		_, _, err := parseBookingsCSV(strings.NewReader(bad), "")
+		assert.Error(t, err, bad)
+	}
+
+	summary := &pb.BulkPurchaseResponse{Succeeded: 1, Failed: 1, Results: []*pb.BulkPurchaseResult{
+		{Row: 1, ReceiptId: "rec-1"},
+		{Row: 2, Error: &pb.BulkPurchaseError{Code: "FailedPrecondition", Message: "payment declined", Reason: "PAYMENT_DECLINED"}},
+	}}
+	stream := &mockBulkPurchaseStream{resp: summary}
+	mockClient := new(MockTicketServiceClient)
+	mockClient.On("BulkPurchase", mock.Anything).Return(stream, nil)
+
+	client := &Client{client: mockClient}
+	resp, err := client.BulkPurchase(context.Background(), reqs)
+	assert.NoError(t, err)
+	assert.Equal(t, reqs, stream.sent)
+	assert.Equal(t, "line 2: rec-1", describeBulkPurchaseResult(lines[0], resp.Results[0]))
+	assert.Equal(t, "line 3: FailedPrecondition: payment declined (PAYMENT_DECLINED)", describeBulkPurchaseResult(lines[1], resp.Results[1]))
+	mockClient.AssertExpectations(t)
+}
+
+func TestSeatSelectionSession(t *testing.T) {
+	pick, err := parseSelectionAction("pick A1", "London", "Paris", "tok_visa")
+	assert.NoError(t, err)
+	confirm, err := parseSelectionAction("confirm Ann Lee ann@example.com Kid Lee kid@example.com", "London", "Paris", "tok_visa")
+	assert.NoError(t, err)
+	assert.Equal(t, "London", confirm.GetConfirm().From)
+	assert.Equal(t, "ann@example.com", confirm.GetConfirm().User.Email)
+	assert.Len(t, confirm.GetConfirm().Passengers, 2)
+	for _, bad := range []string{"pick", "unpick A1 A2", "confirm Ann Lee", "book A1"} {
+		_, err := parseSelectionAction(bad, "London", "Paris", "")
+		assert.Error(t, err, bad)
+	}
+
+	stream := &mockSelectionStream{closed: make(chan struct{}), events: []*pb.SeatSelectionEvent{
+		{Event: &pb.SeatSelectionEvent_Conflict{Conflict: &pb.SeatConflict{Seat: "A1", Message: "seat A1 is already taken", Reason: "SEAT_TAKEN"}}},
+		{Event: &pb.SeatSelectionEvent_Expired{Expired: &pb.Hold{Seats: []string{"B1"}}}},
+		{Event: &pb.SeatSelectionEvent_Confirmed{Confirmed: &pb.PurchaseResponse{ReceiptId: "rec-1"}}},
+	}}
+	mockClient := new(MockTicketServiceClient)
+	mockClient.On("SeatSelectionSession", mock.Anything).Return(stream, nil)
+	client := &Client{client: mockClient}
+
+	actions := make(chan *pb.SeatSelectionRequest, 2)
+	actions <- pick
+	actions <- confirm
+	close(actions)
+	var events []string
+	err = client.SeatSelectionSession(context.Background(), "ES9010-20300901", "ann@example.com", actions, func(event *pb.SeatSelectionEvent) {
+		events = append(events, describeSelectionEvent(event))
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.SeatSelectionRequest{
+		{Action: &pb.SeatSelectionRequest_Start{Start: &pb.StartSelection{DepartureId: "ES9010-20300901", Email: "ann@example.com"}}},
+		pick,
+		confirm,
+	}, stream.sent)
+	assert.Equal(t, []string{
+		"Cannot pick A1: seat A1 is already taken (SEAT_TAKEN)",
+		"Hold on B1 expired, the seats were released",
+		"Purchase Response: rec-1",
+	}, events)
+	mockClient.AssertExpectations(t)
+}
+
+func TestTokenCredentials(t *testing.T) {
+	md, err := tokenCredentials{token: "agent-key"}.GetRequestMetadata(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer agent-key"}, md)
+	// Tokens only go over plaintext when asked to
+	assert.True(t, tokenCredentials{token: "agent-key"}.RequireTransportSecurity())
+	assert.False(t, tokenCredentials{token: "agent-key", insecure: true}.RequireTransportSecurity())
+}
+
+func TestTransportCredentials(t *testing.T) {
+	creds, err := transportCredentials("", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "tls", creds.Info().SecurityProtocol)
+	creds, err = transportCredentials("", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "insecure", creds.Info().SecurityProtocol)
+	_, err = transportCredentials(filepath.Join(t.TempDir(), "missing.pem"), false)
+	assert.Error(t, err)
+}
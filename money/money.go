@@ -0,0 +1,107 @@
+// Package money converts between amounts of a currency and the Money message
+// used by the TicketService, and formats them for display.
+//
+// Amounts are held in minor units (pence, cents) while they are worked on, so
+// sums never pick up floating point error.
+package money
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+)
+
+const nanosPerUnit = 1_000_000_000
+
+// Currency describes an ISO 4217 currency.
+type Currency struct {
+	Code   string
+	Symbol string
+	Digits int // digits of the minor unit, e.g. 2 for pence
+}
+
+var currencies = map[string]Currency{
+	"GBP": {Code: "GBP", Symbol: "£", Digits: 2},
+	"EUR": {Code: "EUR", Symbol: "€", Digits: 2},
+}
+
+// Lookup returns the currency with the given code, ignoring case.
+func Lookup(code string) (Currency, bool) {
+	c, ok := currencies[strings.ToUpper(code)]
+	return c, ok
+}
+
+// minorPerUnit returns the number of minor units in one unit of c.
+func (c Currency) minorPerUnit() int64 {
+	return int64(math.Pow10(c.Digits))
+}
+
+// Round returns amount units of c rounded half away from zero to the minor
+// unit, e.g. 12.345 GBP becomes £12.35.
+func (c Currency) Round(amount float64) *pb.Money {
+	return c.FromMinor(int64(math.Round(amount * float64(c.minorPerUnit()))))
+}
+
+// FromMinor returns minor minor units of c as Money.
+func (c Currency) FromMinor(minor int64) *pb.Money {
+	perUnit := c.minorPerUnit()
+	return &pb.Money{
+		CurrencyCode: c.Code,
+		Units:        minor / perUnit,
+		Nanos:        int32(minor % perUnit * (nanosPerUnit / perUnit)),
+	}
+}
+
+// Minor returns m in minor units of its currency, rounding any finer
+// fraction half away from zero.
+func Minor(m *pb.Money) (int64, error) {
+	c, ok := Lookup(m.GetCurrencyCode())
+	if !ok {
+		return 0, fmt.Errorf("unknown currency %q", m.GetCurrencyCode())
+	}
+	perUnit := c.minorPerUnit()
+	nanosPerMinor := float64(nanosPerUnit / perUnit)
+	return m.GetUnits()*perUnit + int64(math.Round(float64(m.GetNanos())/nanosPerMinor)), nil
+}
+
+// Sum adds amounts, which must all be in c.
+func (c Currency) Sum(amounts ...*pb.Money) (*pb.Money, error) {
+	var total int64
+	for _, m := range amounts {
+		if m.GetCurrencyCode() != c.Code {
+			return nil, fmt.Errorf("cannot add %s to %s", m.GetCurrencyCode(), c.Code)
+		}
+		minor, err := Minor(m)
+		if err != nil {
+			return nil, err
+		}
+		total += minor
+	}
+	return c.FromMinor(total), nil
+}
+
+// Format renders m for display, e.g. "£12.50" or "-€3.05". Amounts in
+// currencies this package does not know are shown with their code,
+// e.g. "12.5 CHF".
+func Format(m *pb.Money) string {
+	if m == nil {
+		return ""
+	}
+	c, ok := Lookup(m.CurrencyCode)
+	if !ok {
+		amount := float64(m.Units) + float64(m.Nanos)/nanosPerUnit
+		return fmt.Sprintf("%g %s", amount, m.CurrencyCode)
+	}
+	minor, _ := Minor(m)
+	sign := ""
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	perUnit := c.minorPerUnit()
+	if c.Digits == 0 {
+		return fmt.Sprintf("%s%s%d", sign, c.Symbol, minor)
+	}
+	return fmt.Sprintf("%s%s%d.%0*d", sign, c.Symbol, minor/perUnit, c.Digits, minor%perUnit)
+}
@@ -0,0 +1,55 @@
+package money
+
+import (
+	"testing"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundAndFormat(t *testing.T) {
+	gbp, ok := Lookup("gbp")
+	require.True(t, ok)
+	eur, _ := Lookup("EUR")
+
+	tests := []struct {
+		name     string
+		money    *pb.Money
+		expected *pb.Money
+		text     string
+	}{
+		{"whole pounds", gbp.Round(20), &pb.Money{CurrencyCode: "GBP", Units: 20}, "£20.00"},
+		{"half rounds up", gbp.Round(12.345), &pb.Money{CurrencyCode: "GBP", Units: 12, Nanos: 350_000_000}, "£12.35"},
+		{"below half rounds down", eur.Round(0.0449), &pb.Money{CurrencyCode: "EUR", Units: 0, Nanos: 40_000_000}, "€0.04"},
+		{"negative", eur.Round(-3.05), &pb.Money{CurrencyCode: "EUR", Units: -3, Nanos: -50_000_000}, "-€3.05"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected.String(), tt.money.String())
+			assert.Equal(t, tt.text, Format(tt.money))
+		})
+	}
+
+	assert.Equal(t, "12.5 CHF", Format(&pb.Money{CurrencyCode: "CHF", Units: 12, Nanos: 500_000_000}))
+	assert.Equal(t, "", Format(nil))
+}
+
+func TestSum(t *testing.T) {
+	gbp, _ := Lookup("GBP")
+	eur, _ := Lookup("EUR")
+
+	// Ten 10p amounts add up exactly, unlike ten float 0.1s.
+	var amounts []*pb.Money
+	for i := 0; i < 10; i++ {
+		amounts = append(amounts, gbp.Round(0.1))
+	}
+	total, err := gbp.Sum(amounts...)
+	require.NoError(t, err)
+	assert.Equal(t, "£1.00", Format(total))
+
+	_, err = gbp.Sum(gbp.Round(1), eur.Round(1))
+	assert.Error(t, err)
+	_, err = Minor(&pb.Money{CurrencyCode: "XXX"})
+	assert.Error(t, err)
+}
@@ -23,6 +23,33 @@ type TicketServiceClient interface {
 	ViewUsersBySection(ctx context.Context, in *ViewUsersRequest, opts ...grpc.CallOption) (*ViewUsersResponse, error)
 	RemoveUser(ctx context.Context, in *RemoveUserRequest, opts ...grpc.CallOption) (*RemoveUserResponse, error)
 	ModifySeat(ctx context.Context, in *ModifySeatRequest, opts ...grpc.CallOption) (*ModifySeatResponse, error)
+	ListStations(ctx context.Context, in *ListStationsRequest, opts ...grpc.CallOption) (*ListStationsResponse, error)
+	ListDepartures(ctx context.Context, in *ListDeparturesRequest, opts ...grpc.CallOption) (*ListDeparturesResponse, error)
+	CancelTicket(ctx context.Context, in *CancelTicketRequest, opts ...grpc.CallOption) (*CancelTicketResponse, error)
+	ListMyTickets(ctx context.Context, in *ListMyTicketsRequest, opts ...grpc.CallOption) (*ListMyTicketsResponse, error)
+	HoldSeats(ctx context.Context, in *HoldSeatsRequest, opts ...grpc.CallOption) (*HoldSeatsResponse, error)
+	ReleaseHold(ctx context.Context, in *ReleaseHoldRequest, opts ...grpc.CallOption) (*ReleaseHoldResponse, error)
+	JoinWaitlist(ctx context.Context, in *JoinWaitlistRequest, opts ...grpc.CallOption) (*JoinWaitlistResponse, error)
+	LeaveWaitlist(ctx context.Context, in *LeaveWaitlistRequest, opts ...grpc.CallOption) (*LeaveWaitlistResponse, error)
+	// WatchWaitlist streams a waitlist entry each time it changes, ending once
+	// the entry is no longer waiting.
+	WatchWaitlist(ctx context.Context, in *WatchWaitlistRequest, opts ...grpc.CallOption) (TicketService_WatchWaitlistClient, error)
+	GetBoardingPass(ctx context.Context, in *GetBoardingPassRequest, opts ...grpc.CallOption) (*BoardingPass, error)
+	ValidateTicket(ctx context.Context, in *ValidateTicketRequest, opts ...grpc.CallOption) (*ValidateTicketResponse, error)
+	RenderReceipt(ctx context.Context, in *RenderReceiptRequest, opts ...grpc.CallOption) (*RenderReceiptResponse, error)
+	// WatchSeatMap streams a snapshot of every seat on a departure and then
+	// the seats whose state changes, until the client goes away.
+	WatchSeatMap(ctx context.Context, in *WatchSeatMapRequest, opts ...grpc.CallOption) (TicketService_WatchSeatMapClient, error)
+	// BulkPurchase buys a ticket for each request streamed to it, as
+	// PurchaseTicket would, and reports on every one when the stream closes.
+	// A purchase that fails does not stop the others.
+	BulkPurchase(ctx context.Context, opts ...grpc.CallOption) (TicketService_BulkPurchaseClient, error)
+	// SeatSelectionSession lets a client pick seats interactively. It holds
+	// the seats the client picks, reports seats it cannot have and seat map
+	// changes as they happen, warns before the hold expires and buys the
+	// seats when the client confirms. Seats still held when the session ends
+	// are released.
+	SeatSelectionSession(ctx context.Context, opts ...grpc.CallOption) (TicketService_SeatSelectionSessionClient, error)
 }
 
 type ticketServiceClient struct {
@@ -78,6 +105,234 @@ func (c *ticketServiceClient) ModifySeat(ctx context.Context, in *ModifySeatRequ
 	return out, nil
 }
 
+func (c *ticketServiceClient) ListStations(ctx context.Context, in *ListStationsRequest, opts ...grpc.CallOption) (*ListStationsResponse, error) {
+	out := new(ListStationsResponse)
+	err := c.cc.Invoke(ctx, "/TicketService/ListStations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) ListDepartures(ctx context.Context, in *ListDeparturesRequest, opts ...grpc.CallOption) (*ListDeparturesResponse, error) {
+	out := new(ListDeparturesResponse)
+	err := c.cc.Invoke(ctx, "/TicketService/ListDepartures", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) CancelTicket(ctx context.Context, in *CancelTicketRequest, opts ...grpc.CallOption) (*CancelTicketResponse, error) {
+	out := new(CancelTicketResponse)
+	err := c.cc.Invoke(ctx, "/TicketService/CancelTicket", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) ListMyTickets(ctx context.Context, in *ListMyTicketsRequest, opts ...grpc.CallOption) (*ListMyTicketsResponse, error) {
+	out := new(ListMyTicketsResponse)
+	err := c.cc.Invoke(ctx, "/TicketService/ListMyTickets", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) HoldSeats(ctx context.Context, in *HoldSeatsRequest, opts ...grpc.CallOption) (*HoldSeatsResponse, error) {
+	out := new(HoldSeatsResponse)
+	err := c.cc.Invoke(ctx, "/TicketService/HoldSeats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) ReleaseHold(ctx context.Context, in *ReleaseHoldRequest, opts ...grpc.CallOption) (*ReleaseHoldResponse, error) {
+	out := new(ReleaseHoldResponse)
+	err := c.cc.Invoke(ctx, "/TicketService/ReleaseHold", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) JoinWaitlist(ctx context.Context, in *JoinWaitlistRequest, opts ...grpc.CallOption) (*JoinWaitlistResponse, error) {
+	out := new(JoinWaitlistResponse)
+	err := c.cc.Invoke(ctx, "/TicketService/JoinWaitlist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) LeaveWaitlist(ctx context.Context, in *LeaveWaitlistRequest, opts ...grpc.CallOption) (*LeaveWaitlistResponse, error) {
+	out := new(LeaveWaitlistResponse)
+	err := c.cc.Invoke(ctx, "/TicketService/LeaveWaitlist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) WatchWaitlist(ctx context.Context, in *WatchWaitlistRequest, opts ...grpc.CallOption) (TicketService_WatchWaitlistClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TicketService_ServiceDesc.Streams[0], "/TicketService/WatchWaitlist", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ticketServiceWatchWaitlistClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TicketService_WatchWaitlistClient interface {
+	Recv() (*WaitlistEntry, error)
+	grpc.ClientStream
+}
+
+type ticketServiceWatchWaitlistClient struct {
+	grpc.ClientStream
+}
+
+func (x *ticketServiceWatchWaitlistClient) Recv() (*WaitlistEntry, error) {
+	m := new(WaitlistEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *ticketServiceClient) GetBoardingPass(ctx context.Context, in *GetBoardingPassRequest, opts ...grpc.CallOption) (*BoardingPass, error) {
+	out := new(BoardingPass)
+	err := c.cc.Invoke(ctx, "/TicketService/GetBoardingPass", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) ValidateTicket(ctx context.Context, in *ValidateTicketRequest, opts ...grpc.CallOption) (*ValidateTicketResponse, error) {
+	out := new(ValidateTicketResponse)
+	err := c.cc.Invoke(ctx, "/TicketService/ValidateTicket", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) RenderReceipt(ctx context.Context, in *RenderReceiptRequest, opts ...grpc.CallOption) (*RenderReceiptResponse, error) {
+	out := new(RenderReceiptResponse)
+	err := c.cc.Invoke(ctx, "/TicketService/RenderReceipt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketServiceClient) WatchSeatMap(ctx context.Context, in *WatchSeatMapRequest, opts ...grpc.CallOption) (TicketService_WatchSeatMapClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TicketService_ServiceDesc.Streams[1], "/TicketService/WatchSeatMap", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ticketServiceWatchSeatMapClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TicketService_WatchSeatMapClient interface {
+	Recv() (*SeatMapEvent, error)
+	grpc.ClientStream
+}
+
+type ticketServiceWatchSeatMapClient struct {
+	grpc.ClientStream
+}
+
+func (x *ticketServiceWatchSeatMapClient) Recv() (*SeatMapEvent, error) {
+	m := new(SeatMapEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *ticketServiceClient) BulkPurchase(ctx context.Context, opts ...grpc.CallOption) (TicketService_BulkPurchaseClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TicketService_ServiceDesc.Streams[2], "/TicketService/BulkPurchase", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ticketServiceBulkPurchaseClient{stream}
+	return x, nil
+}
+
+type TicketService_BulkPurchaseClient interface {
+	Send(*PurchaseRequest) error
+	CloseAndRecv() (*BulkPurchaseResponse, error)
+	grpc.ClientStream
+}
+
+type ticketServiceBulkPurchaseClient struct {
+	grpc.ClientStream
+}
+
+func (x *ticketServiceBulkPurchaseClient) Send(m *PurchaseRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *ticketServiceBulkPurchaseClient) CloseAndRecv() (*BulkPurchaseResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(BulkPurchaseResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *ticketServiceClient) SeatSelectionSession(ctx context.Context, opts ...grpc.CallOption) (TicketService_SeatSelectionSessionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TicketService_ServiceDesc.Streams[3], "/TicketService/SeatSelectionSession", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ticketServiceSeatSelectionSessionClient{stream}
+	return x, nil
+}
+
+type TicketService_SeatSelectionSessionClient interface {
+	Send(*SeatSelectionRequest) error
+	Recv() (*SeatSelectionEvent, error)
+	grpc.ClientStream
+}
+
+type ticketServiceSeatSelectionSessionClient struct {
+	grpc.ClientStream
+}
+
+func (x *ticketServiceSeatSelectionSessionClient) Send(m *SeatSelectionRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *ticketServiceSeatSelectionSessionClient) Recv() (*SeatSelectionEvent, error) {
+	m := new(SeatSelectionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // TicketServiceServer is the server API for TicketService service.
 // All implementations must embed UnimplementedTicketServiceServer
 // for forward compatibility
@@ -87,6 +342,33 @@ type TicketServiceServer interface {
 	ViewUsersBySection(context.Context, *ViewUsersRequest) (*ViewUsersResponse, error)
 	RemoveUser(context.Context, *RemoveUserRequest) (*RemoveUserResponse, error)
 	ModifySeat(context.Context, *ModifySeatRequest) (*ModifySeatResponse, error)
+	ListStations(context.Context, *ListStationsRequest) (*ListStationsResponse, error)
+	ListDepartures(context.Context, *ListDeparturesRequest) (*ListDeparturesResponse, error)
+	CancelTicket(context.Context, *CancelTicketRequest) (*CancelTicketResponse, error)
+	ListMyTickets(context.Context, *ListMyTicketsRequest) (*ListMyTicketsResponse, error)
+	HoldSeats(context.Context, *HoldSeatsRequest) (*HoldSeatsResponse, error)
+	ReleaseHold(context.Context, *ReleaseHoldRequest) (*ReleaseHoldResponse, error)
+	JoinWaitlist(context.Context, *JoinWaitlistRequest) (*JoinWaitlistResponse, error)
+	LeaveWaitlist(context.Context, *LeaveWaitlistRequest) (*LeaveWaitlistResponse, error)
+	// WatchWaitlist streams a waitlist entry each time it changes, ending once
+	// the entry is no longer waiting.
+	WatchWaitlist(*WatchWaitlistRequest, TicketService_WatchWaitlistServer) error
+	GetBoardingPass(context.Context, *GetBoardingPassRequest) (*BoardingPass, error)
+	ValidateTicket(context.Context, *ValidateTicketRequest) (*ValidateTicketResponse, error)
+	RenderReceipt(context.Context, *RenderReceiptRequest) (*RenderReceiptResponse, error)
+	// WatchSeatMap streams a snapshot of every seat on a departure and then
+	// the seats whose state changes, until the client goes away.
+	WatchSeatMap(*WatchSeatMapRequest, TicketService_WatchSeatMapServer) error
+	// BulkPurchase buys a ticket for each request streamed to it, as
+	// PurchaseTicket would, and reports on every one when the stream closes.
+	// A purchase that fails does not stop the others.
+	BulkPurchase(TicketService_BulkPurchaseServer) error
+	// SeatSelectionSession lets a client pick seats interactively. It holds
+	// the seats the client picks, reports seats it cannot have and seat map
+	// changes as they happen, warns before the hold expires and buys the
+	// seats when the client confirms. Seats still held when the session ends
+	// are released.
+	SeatSelectionSession(TicketService_SeatSelectionSessionServer) error
 	mustEmbedUnimplementedTicketServiceServer()
 }
 
@@ -109,6 +391,51 @@ func (UnimplementedTicketServiceServer) RemoveUser(context.Context, *RemoveUserR
 func (UnimplementedTicketServiceServer) ModifySeat(context.Context, *ModifySeatRequest) (*ModifySeatResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ModifySeat not implemented")
 }
+func (UnimplementedTicketServiceServer) ListStations(context.Context, *ListStationsRequest) (*ListStationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStations not implemented")
+}
+func (UnimplementedTicketServiceServer) ListDepartures(context.Context, *ListDeparturesRequest) (*ListDeparturesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDepartures not implemented")
+}
+func (UnimplementedTicketServiceServer) CancelTicket(context.Context, *CancelTicketRequest) (*CancelTicketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTicket not implemented")
+}
+func (UnimplementedTicketServiceServer) ListMyTickets(context.Context, *ListMyTicketsRequest) (*ListMyTicketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMyTickets not implemented")
+}
+func (UnimplementedTicketServiceServer) HoldSeats(context.Context, *HoldSeatsRequest) (*HoldSeatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HoldSeats not implemented")
+}
+func (UnimplementedTicketServiceServer) ReleaseHold(context.Context, *ReleaseHoldRequest) (*ReleaseHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseHold not implemented")
+}
+func (UnimplementedTicketServiceServer) JoinWaitlist(context.Context, *JoinWaitlistRequest) (*JoinWaitlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinWaitlist not implemented")
+}
+func (UnimplementedTicketServiceServer) LeaveWaitlist(context.Context, *LeaveWaitlistRequest) (*LeaveWaitlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveWaitlist not implemented")
+}
+func (UnimplementedTicketServiceServer) WatchWaitlist(*WatchWaitlistRequest, TicketService_WatchWaitlistServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchWaitlist not implemented")
+}
+func (UnimplementedTicketServiceServer) GetBoardingPass(context.Context, *GetBoardingPassRequest) (*BoardingPass, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBoardingPass not implemented")
+}
+func (UnimplementedTicketServiceServer) ValidateTicket(context.Context, *ValidateTicketRequest) (*ValidateTicketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateTicket not implemented")
+}
+func (UnimplementedTicketServiceServer) RenderReceipt(context.Context, *RenderReceiptRequest) (*RenderReceiptResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderReceipt not implemented")
+}
+func (UnimplementedTicketServiceServer) WatchSeatMap(*WatchSeatMapRequest, TicketService_WatchSeatMapServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSeatMap not implemented")
+}
+func (UnimplementedTicketServiceServer) BulkPurchase(TicketService_BulkPurchaseServer) error {
+	return status.Errorf(codes.Unimplemented, "method BulkPurchase not implemented")
+}
+func (UnimplementedTicketServiceServer) SeatSelectionSession(TicketService_SeatSelectionSessionServer) error {
+	return status.Errorf(codes.Unimplemented, "method SeatSelectionSession not implemented")
+}
 func (UnimplementedTicketServiceServer) mustEmbedUnimplementedTicketServiceServer() {}
 
 // UnsafeTicketServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -212,6 +539,298 @@ func _TicketService_ModifySeat_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TicketService_ListStations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).ListStations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TicketService/ListStations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).ListStations(ctx, req.(*ListStationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_ListDepartures_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeparturesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).ListDepartures(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TicketService/ListDepartures",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).ListDepartures(ctx, req.(*ListDeparturesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_CancelTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).CancelTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TicketService/CancelTicket",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).CancelTicket(ctx, req.(*CancelTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_ListMyTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMyTicketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).ListMyTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TicketService/ListMyTickets",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).ListMyTickets(ctx, req.(*ListMyTicketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_HoldSeats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldSeatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).HoldSeats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TicketService/HoldSeats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).HoldSeats(ctx, req.(*HoldSeatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_ReleaseHold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseHoldRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).ReleaseHold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TicketService/ReleaseHold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).ReleaseHold(ctx, req.(*ReleaseHoldRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_JoinWaitlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinWaitlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).JoinWaitlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TicketService/JoinWaitlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).JoinWaitlist(ctx, req.(*JoinWaitlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_LeaveWaitlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveWaitlistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).LeaveWaitlist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TicketService/LeaveWaitlist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).LeaveWaitlist(ctx, req.(*LeaveWaitlistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_WatchWaitlist_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchWaitlistRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TicketServiceServer).WatchWaitlist(m, &ticketServiceWatchWaitlistServer{stream})
+}
+
+type TicketService_WatchWaitlistServer interface {
+	Send(*WaitlistEntry) error
+	grpc.ServerStream
+}
+
+type ticketServiceWatchWaitlistServer struct {
+	grpc.ServerStream
+}
+
+func (x *ticketServiceWatchWaitlistServer) Send(m *WaitlistEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TicketService_GetBoardingPass_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBoardingPassRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).GetBoardingPass(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TicketService/GetBoardingPass",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).GetBoardingPass(ctx, req.(*GetBoardingPassRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_ValidateTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).ValidateTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TicketService/ValidateTicket",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).ValidateTicket(ctx, req.(*ValidateTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_RenderReceipt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderReceiptRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketServiceServer).RenderReceipt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/TicketService/RenderReceipt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketServiceServer).RenderReceipt(ctx, req.(*RenderReceiptRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TicketService_WatchSeatMap_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSeatMapRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TicketServiceServer).WatchSeatMap(m, &ticketServiceWatchSeatMapServer{stream})
+}
+
+type TicketService_WatchSeatMapServer interface {
+	Send(*SeatMapEvent) error
+	grpc.ServerStream
+}
+
+type ticketServiceWatchSeatMapServer struct {
+	grpc.ServerStream
+}
+
+func (x *ticketServiceWatchSeatMapServer) Send(m *SeatMapEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TicketService_BulkPurchase_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TicketServiceServer).BulkPurchase(&ticketServiceBulkPurchaseServer{stream})
+}
+
+type TicketService_BulkPurchaseServer interface {
+	SendAndClose(*BulkPurchaseResponse) error
+	Recv() (*PurchaseRequest, error)
+	grpc.ServerStream
+}
+
+type ticketServiceBulkPurchaseServer struct {
+	grpc.ServerStream
+}
+
+func (x *ticketServiceBulkPurchaseServer) SendAndClose(m *BulkPurchaseResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *ticketServiceBulkPurchaseServer) Recv() (*PurchaseRequest, error) {
+	m := new(PurchaseRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _TicketService_SeatSelectionSession_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(TicketServiceServer).SeatSelectionSession(&ticketServiceSeatSelectionSessionServer{stream})
+}
+
+type TicketService_SeatSelectionSessionServer interface {
+	Send(*SeatSelectionEvent) error
+	Recv() (*SeatSelectionRequest, error)
+	grpc.ServerStream
+}
+
+type ticketServiceSeatSelectionSessionServer struct {
+	grpc.ServerStream
+}
+
+func (x *ticketServiceSeatSelectionSessionServer) Send(m *SeatSelectionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *ticketServiceSeatSelectionSessionServer) Recv() (*SeatSelectionRequest, error) {
+	m := new(SeatSelectionRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // TicketService_ServiceDesc is the grpc.ServiceDesc for TicketService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -239,7 +858,73 @@ var TicketService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ModifySeat",
 			Handler:    _TicketService_ModifySeat_Handler,
 		},
+		{
+			MethodName: "ListStations",
+			Handler:    _TicketService_ListStations_Handler,
+		},
+		{
+			MethodName: "ListDepartures",
+			Handler:    _TicketService_ListDepartures_Handler,
+		},
+		{
+			MethodName: "CancelTicket",
+			Handler:    _TicketService_CancelTicket_Handler,
+		},
+		{
+			MethodName: "ListMyTickets",
+			Handler:    _TicketService_ListMyTickets_Handler,
+		},
+		{
+			MethodName: "HoldSeats",
+			Handler:    _TicketService_HoldSeats_Handler,
+		},
+		{
+			MethodName: "ReleaseHold",
+			Handler:    _TicketService_ReleaseHold_Handler,
+		},
+		{
+			MethodName: "JoinWaitlist",
+			Handler:    _TicketService_JoinWaitlist_Handler,
+		},
+		{
+			MethodName: "LeaveWaitlist",
+			Handler:    _TicketService_LeaveWaitlist_Handler,
+		},
+		{
+			MethodName: "GetBoardingPass",
+			Handler:    _TicketService_GetBoardingPass_Handler,
+		},
+		{
+			MethodName: "ValidateTicket",
+			Handler:    _TicketService_ValidateTicket_Handler,
+		},
+		{
+			MethodName: "RenderReceipt",
+			Handler:    _TicketService_RenderReceipt_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchWaitlist",
+			Handler:       _TicketService_WatchWaitlist_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchSeatMap",
+			Handler:       _TicketService_WatchSeatMap_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "BulkPurchase",
+			Handler:       _TicketService_BulkPurchase_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SeatSelectionSession",
+			Handler:       _TicketService_SeatSelectionSession_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "train_ticket.proto",
 }
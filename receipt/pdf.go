@@ -0,0 +1,84 @@
+package receipt
+
+import (
+	"io"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Widths of the passenger table columns on an A4 page, in millimetres.
+var passengerColumns = []float64{55, 20, 25, 15, 30, 45}
+
+// renderPDF lays v out on an A4 page with the same fields as the templates.
+func renderPDF(w io.Writer, v *view) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("Receipt "+v.ReceiptID, true)
+	pdf.AddPage()
+	// The core fonts are Windows-1252, which covers £ and €
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	line := func(text string) {
+		pdf.CellFormat(0, 6, tr(text), "", 1, "L", false, 0, "")
+	}
+
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 10, tr("Train ticket receipt "+v.ReceiptID), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 11)
+	line("Status: " + v.Status)
+	line("Booked by: " + v.BookedBy)
+	pdf.Ln(4)
+
+	pdf.SetFont("Helvetica", "B", 13)
+	line("Journey")
+	pdf.SetFont("Helvetica", "", 11)
+	line(v.From + " -> " + v.To)
+	departure := "Departure: " + v.DepartureID
+	if v.Train != "" {
+		departure += " (" + v.Train + ")"
+	}
+	line(departure)
+	if v.Departs != "" {
+		line("Departs: " + v.Departs)
+	}
+	pdf.Ln(4)
+
+	pdf.SetFont("Helvetica", "B", 13)
+	line("Passengers")
+	row := func(cells ...string) {
+		for i, cell := range cells {
+			align := "L"
+			if i == 4 {
+				align = "R"
+			}
+			pdf.CellFormat(passengerColumns[i], 7, tr(cell), "B", 0, align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+	pdf.SetFont("Helvetica", "B", 10)
+	row("Passenger", "Type", "Section", "Seat", "Fare", "Status")
+	pdf.SetFont("Helvetica", "", 10)
+	for _, p := range v.Passengers {
+		row(p.Name, p.Type, p.Section, p.Seat, p.Fare, p.Status)
+	}
+	pdf.SetFont("Helvetica", "B", 10)
+	row("Total paid", "", "", "", v.Total, "")
+	pdf.SetFont("Helvetica", "", 11)
+	if v.PaymentID != "" {
+		pdf.Ln(2)
+		line("Payment reference: " + v.PaymentID)
+	}
+
+	if len(v.Refunds) > 0 {
+		pdf.Ln(4)
+		pdf.SetFont("Helvetica", "B", 13)
+		line("Refunds")
+		pdf.SetFont("Helvetica", "", 10)
+		for _, r := range v.Refunds {
+			pdf.CellFormat(30, 7, tr(r.Date), "B", 0, "L", false, 0, "")
+			pdf.CellFormat(90, 7, tr(r.Passengers), "B", 0, "L", false, 0, "")
+			pdf.CellFormat(40, 7, tr(r.Rule), "B", 0, "L", false, 0, "")
+			pdf.CellFormat(30, 7, tr(r.Amount), "B", 1, "R", false, 0, "")
+		}
+	}
+
+	return pdf.Output(w)
+}
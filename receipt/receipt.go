@@ -0,0 +1,206 @@
+// Package receipt renders printable copies of TicketService receipts as plain
+// text, HTML or PDF.
+//
+// Text and HTML are drawn from the templates in templates/; PDF is laid out
+// with the same fields in code.
+package receipt
+
+import (
+	"embed"
+	"errors"
+	htmltemplate "html/template"
+	"io"
+	"strings"
+	texttemplate "text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Aravinthvvs/gRPC/money"
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+)
+
+// ErrUnknownFormat is returned by Render for formats it cannot produce.
+var ErrUnknownFormat = errors.New("unknown receipt format")
+
+//go:embed templates
+var templates embed.FS
+
+var (
+	textTemplate = texttemplate.Must(texttemplate.New("receipt.txt.tmpl").
+			Funcs(texttemplate.FuncMap{"left": padLeft, "right": padRight}).
+			ParseFS(templates, "templates/receipt.txt.tmpl"))
+	htmlTemplate = htmltemplate.Must(htmltemplate.ParseFS(templates, "templates/receipt.html.tmpl"))
+)
+
+// Document is a receipt together with the journey details it refers to,
+// which the receipt itself only carries as IDs.
+type Document struct {
+	Receipt   *pb.ReceiptResponse
+	From, To  string    // station names
+	Train     string    // train name, empty if unknown
+	DepartsAt time.Time // when the train leaves From, zero if unknown
+}
+
+// Render writes d to w in format. An unspecified format renders plain text.
+func Render(w io.Writer, d *Document, format pb.ReceiptFormat) error {
+	v := newView(d)
+	switch format {
+	case pb.ReceiptFormat_RECEIPT_FORMAT_UNSPECIFIED, pb.ReceiptFormat_RECEIPT_FORMAT_TEXT:
+		return textTemplate.Execute(w, v)
+	case pb.ReceiptFormat_RECEIPT_FORMAT_HTML:
+		return htmlTemplate.Execute(w, v)
+	case pb.ReceiptFormat_RECEIPT_FORMAT_PDF:
+		return renderPDF(w, v)
+	}
+	return ErrUnknownFormat
+}
+
+// ContentType returns the MIME type of receipts rendered in format.
+func ContentType(format pb.ReceiptFormat) string {
+	switch format {
+	case pb.ReceiptFormat_RECEIPT_FORMAT_HTML:
+		return "text/html; charset=utf-8"
+	case pb.ReceiptFormat_RECEIPT_FORMAT_PDF:
+		return "application/pdf"
+	}
+	return "text/plain; charset=utf-8"
+}
+
+// Extension returns the file extension of receipts rendered in format,
+// including the dot.
+func Extension(format pb.ReceiptFormat) string {
+	switch format {
+	case pb.ReceiptFormat_RECEIPT_FORMAT_HTML:
+		return ".html"
+	case pb.ReceiptFormat_RECEIPT_FORMAT_PDF:
+		return ".pdf"
+	}
+	return ".txt"
+}
+
+// view holds the receipt fields as the templates print them.
+type view struct {
+	ReceiptID   string
+	Status      string
+	BookedBy    string
+	From, To    string
+	DepartureID string
+	Train       string
+	Departs     string
+	Passengers  []passengerRow
+	Total       string
+	PaymentID   string
+	Refunds     []refundRow
+}
+
+type passengerRow struct {
+	Name    string
+	Type    string
+	Section string
+	Seat    string
+	Fare    string
+	Status  string // "confirmed", "checked in" or "cancelled"
+}
+
+type refundRow struct {
+	ID         string
+	Date       string
+	Passengers string
+	Rule       string
+	Amount     string
+}
+
+func newView(d *Document) *view {
+	r := d.Receipt
+	v := &view{
+		ReceiptID:   r.ReceiptId,
+		Status:      "confirmed",
+		BookedBy:    userName(r.User),
+		From:        d.From,
+		To:          d.To,
+		DepartureID: r.DepartureId,
+		Train:       d.Train,
+		Total:       money.Format(r.PricePaid),
+		PaymentID:   r.PaymentId,
+	}
+	if r.Status == pb.BookingStatus_BOOKING_STATUS_CANCELLED {
+		v.Status = "cancelled"
+	}
+	if !d.DepartsAt.IsZero() {
+		v.Departs = d.DepartsAt.Format("Mon 2 Jan 2006 15:04")
+	}
+
+	fares := make(map[string]*pb.FareLine)
+	for _, line := range r.GetFare().GetLines() {
+		fares[line.PassengerEmail] = line
+	}
+	addPassengers := func(lines []*pb.SeatLine, status string) {
+		for _, line := range lines {
+			row := passengerRow{
+				Name:    userName(line.Passenger),
+				Type:    passengerType(line.Passenger.GetPassengerType()),
+				Section: line.Section,
+				Seat:    line.Seat,
+				Status:  status,
+			}
+			if fare, ok := fares[line.Passenger.GetEmail()]; ok {
+				row.Fare = money.Format(fare.Amount)
+			}
+			if status == "confirmed" && line.CheckedInAt != nil {
+				row.Status = "checked in"
+			}
+			v.Passengers = append(v.Passengers, row)
+		}
+	}
+	lines := r.SeatLines
+	if len(lines) == 0 && r.Status == pb.BookingStatus_BOOKING_STATUS_UNSPECIFIED {
+		// Receipts written before party bookings only carry a single seat
+		lines = []*pb.SeatLine{{Passenger: r.User, Seat: r.Seat, Section: r.Section}}
+	}
+	addPassengers(lines, "confirmed")
+	addPassengers(r.CancelledSeatLines, "cancelled")
+
+	for _, refund := range r.Refunds {
+		v.Refunds = append(v.Refunds, refundRow{
+			ID:         refund.RefundId,
+			Date:       refund.CreatedAt.AsTime().Format("2 Jan 2006"),
+			Passengers: strings.Join(refund.PassengerEmails, ", "),
+			Rule:       strings.ReplaceAll(refund.Rule, "_", " "),
+			Amount:     money.Format(refund.Amount),
+		})
+	}
+	return v
+}
+
+// userName returns u's full name, or their email if the name is unknown.
+func userName(u *pb.User) string {
+	if name := strings.TrimSpace(u.GetFirstName() + " " + u.GetLastName()); name != "" {
+		return name
+	}
+	return u.GetEmail()
+}
+
+// passengerType renders t for display, e.g. "child".
+func passengerType(t pb.PassengerType) string {
+	if t == pb.PassengerType_PASSENGER_TYPE_UNSPECIFIED {
+		t = pb.PassengerType_PASSENGER_TYPE_ADULT
+	}
+	return strings.ToLower(strings.TrimPrefix(t.String(), "PASSENGER_TYPE_"))
+}
+
+// padLeft left-aligns s in a column width characters wide. Unlike printf's
+// widths it counts characters rather than bytes, so £ and € line up.
+func padLeft(width int, s string) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return s + strings.Repeat(" ", width-n)
+	}
+	return s
+}
+
+// padRight right-aligns s in a column width characters wide.
+func padRight(width int, s string) string {
+	if n := utf8.RuneCountInString(s); n < width {
+		return strings.Repeat(" ", width-n) + s
+	}
+	return s
+}
@@ -0,0 +1,93 @@
+package receipt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Aravinthvvs/gRPC/money"
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func testDocument() *Document {
+	gbp, _ := money.Lookup("GBP")
+	adult := &pb.User{FirstName: "Jane", LastName: "Doe", Email: "jane.doe@example.com"}
+	child := &pb.User{FirstName: "Kid", LastName: "<Doe>", Email: "kid.doe@example.com", PassengerType: pb.PassengerType_PASSENGER_TYPE_CHILD}
+	return &Document{
+		Receipt: &pb.ReceiptResponse{
+			ReceiptId:   "rec-1",
+			User:        adult,
+			DepartureId: "ES9010-20300901",
+			Status:      pb.BookingStatus_BOOKING_STATUS_CONFIRMED,
+			SeatLines: []*pb.SeatLine{
+				{Passenger: adult, Seat: "A1", Section: "SectionA", CheckedInAt: timestamppb.New(time.Date(2030, 9, 1, 7, 45, 0, 0, time.UTC))},
+			},
+			CancelledSeatLines: []*pb.SeatLine{{Passenger: child, Seat: "A2", Section: "SectionA"}},
+			Fare: &pb.FareBreakdown{Lines: []*pb.FareLine{
+				{PassengerEmail: adult.Email, Amount: gbp.Round(126)},
+				{PassengerEmail: child.Email, Amount: gbp.Round(63)},
+			}},
+			PricePaid: gbp.Round(189),
+			PaymentId: "auth-1",
+			Refunds: []*pb.Refund{{
+				RefundId:        "rec-1-refund-1",
+				PassengerEmails: []string{child.Email},
+				Amount:          gbp.Round(31.5),
+				Rule:            "partial",
+				CreatedAt:       timestamppb.New(time.Date(2030, 9, 1, 6, 0, 0, 0, time.UTC)),
+			}},
+		},
+		From:      "London St Pancras",
+		To:        "Paris Gare du Nord",
+		Train:     "Eurostar 9010",
+		DepartsAt: time.Date(2030, 9, 1, 8, 1, 0, 0, time.UTC),
+	}
+}
+
+func render(t *testing.T, format pb.ReceiptFormat) string {
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, testDocument(), format))
+	return buf.String()
+}
+
+func TestRenderText(t *testing.T) {
+	text := render(t, pb.ReceiptFormat_RECEIPT_FORMAT_TEXT)
+	assert.Equal(t, text, render(t, pb.ReceiptFormat_RECEIPT_FORMAT_UNSPECIFIED))
+	for _, want := range []string{
+		"TRAIN TICKET RECEIPT rec-1",
+		"Journey:   London St Pancras -> Paris Gare du Nord",
+		"Departure: ES9010-20300901 (Eurostar 9010)",
+		"Departs:   Sun 1 Sep 2030 08:01",
+		"Jane Doe                 adult   SectionA  A1       £126.00  checked in",
+		"Kid <Doe>                child   SectionA  A2        £63.00  cancelled",
+		"Total paid                                          £189.00",
+		"Payment reference: auth-1",
+		"1 Sep 2030   kid.doe@example.com      partial              £31.50",
+	} {
+		assert.Contains(t, text, want)
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	html := render(t, pb.ReceiptFormat_RECEIPT_FORMAT_HTML)
+	assert.True(t, strings.HasPrefix(html, "<!DOCTYPE html>"))
+	assert.Contains(t, html, "<title>Receipt rec-1</title>")
+	assert.Contains(t, html, `<tr class="cancelled"><td>Kid &lt;Doe&gt;</td>`)
+	assert.Contains(t, html, "£189.00")
+}
+
+func TestRenderPDF(t *testing.T) {
+	pdf := render(t, pb.ReceiptFormat_RECEIPT_FORMAT_PDF)
+	assert.True(t, strings.HasPrefix(pdf, "%PDF-"))
+	assert.Equal(t, "application/pdf", ContentType(pb.ReceiptFormat_RECEIPT_FORMAT_PDF))
+	assert.Equal(t, ".pdf", Extension(pb.ReceiptFormat_RECEIPT_FORMAT_PDF))
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	err := Render(&bytes.Buffer{}, testDocument(), pb.ReceiptFormat(42))
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+)
+
+// SectionAllocator chooses the section a new passenger is seated in.
+type SectionAllocator interface {
+	// Allocate picks one of open, the sections of the departure that still
+	// have free seats. open is never empty and is in the train's layout order.
+	Allocate(req *pb.PurchaseRequest, seats *seatMap, open []*sectionLayout) *sectionLayout
+}
+
+// newSectionAllocator returns the allocator registered under name. seed is
+// only used by the random allocator. Whatever the strategy, passengers are
+// seated in the section they ask for while it has room; "preference" is
+// kept as another name for the balanced strategy.
+func newSectionAllocator(name string, seed int64) (SectionAllocator, error) {
+	var fallback SectionAllocator
+	switch name {
+	case "balanced", "preference":
+		fallback = balancedAllocator{}
+	case "fill-first":
+		fallback = fillFirstAllocator{}
+	case "random":
+		fallback = newRandomAllocator(seed)
+	default:
+		return nil, fmt.Errorf("unknown section allocator %q", name)
+	}
+	return preferenceAllocator{fallback: fallback}, nil
+}
+
+// balancedAllocator seats passengers in the section with the most free seats,
+// keeping sections evenly filled.
+type balancedAllocator struct{}
+
+func (balancedAllocator) Allocate(req *pb.PurchaseRequest, seats *seatMap, open []*sectionLayout) *sectionLayout {
+	best := open[0]
+	for _, l := range open[1:] {
+		if len(seats.freeSeats(l)) > len(seats.freeSeats(best)) {
+			best = l
+		}
+	}
+	return best
+}
+
+// fillFirstAllocator fills sections one after another in layout order.
+type fillFirstAllocator struct{}
+
+func (fillFirstAllocator) Allocate(req *pb.PurchaseRequest, seats *seatMap, open []*sectionLayout) *sectionLayout {
+	return open[0]
+}
+
+// preferenceAllocator honours the passenger's preferred section when it has
+// room and defers to fallback otherwise.
+type preferenceAllocator struct {
+	fallback SectionAllocator
+}
+
+func (a preferenceAllocator) Allocate(req *pb.PurchaseRequest, seats *seatMap, open []*sectionLayout) *sectionLayout {
+	for _, l := range open {
+		if l.Name == req.PreferredSection {
+			return l
+		}
+	}
+	return a.fallback.Allocate(req, seats, open)
+}
+
+// randomAllocator picks uniformly among open sections from a seeded source,
+// so a given seed always produces the same assignments.
+type randomAllocator struct {
+	rng *rand.Rand
+}
+
+func newRandomAllocator(seed int64) *randomAllocator {
+	return &randomAllocator{rng: rand.New(rand.NewSource(seed))}
+}
+
+func (a *randomAllocator) Allocate(req *pb.PurchaseRequest, seats *seatMap, open []*sectionLayout) *sectionLayout {
+	return open[a.rng.Intn(len(open))]
+}
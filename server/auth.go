@@ -0,0 +1,332 @@
+package main
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// Ways a caller can authenticate, as recorded in identity.via.
+const (
+	authViaJWT    = "jwt"
+	authViaAPIKey = "api_key"
+)
+
+// tokenLeeway is how far clocks may disagree when checking a token's
+// expiry and not-before times.
+const tokenLeeway = 30 * time.Second
+
+// identity is the authenticated caller of an RPC.
+type identity struct {
+	// subject names the caller: the sub claim of a token, or the name of an
+	// API key.
+	subject string
+	// email is the passenger the caller is, if it is one.
+	email string
+	// roles are checked against the access policy.
+	roles []string
+	via   string
+}
+
+type identityKey struct{}
+
+// withIdentity returns ctx carrying the caller id.
+func withIdentity(ctx context.Context, id *identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// callerIdentity returns the caller attached to ctx by the auth interceptors,
+// or nil if the server does not authenticate callers.
+func callerIdentity(ctx context.Context) *identity {
+	id, _ := ctx.Value(identityKey{}).(*identity)
+	return id
+}
+
+// authConfig is the JSON file read by loadAuthenticator. Tokens are checked
+// against the HMAC secret or the keys of a JWKS file; API keys are compared
+// by their SHA-256 digest.
+type authConfig struct {
+	Issuer     string `json:"issuer"`
+	Audience   string `json:"audience"`
+	HMACSecret string `json:"hmac_secret"`
+	// JWKSFile is relative to the directory of the config file.
+	JWKSFile string `json:"jwks_file"`
+	APIKeys  []struct {
+		Name  string   `json:"name"`
+		Key   string   `json:"key"`
+		Email string   `json:"email"`
+		Roles []string `json:"roles"`
+	} `json:"api_keys"`
+}
+
+// authenticator checks the credentials sent with every RPC: a bearer JWT in
+// the authorization header, or a static API key either there or in the
+// x-api-key header.
+type authenticator struct {
+	issuer     string
+	audience   string
+	hmacSecret []byte
+	// jwks holds public keys by key ID.
+	jwks map[string]crypto.PublicKey
+	// apiKeys holds the callers of API keys by the SHA-256 digest of the key.
+	apiKeys map[[sha256.Size]byte]*identity
+	now     func() time.Time
+}
+
+// loadAuthenticator reads the keys callers authenticate with from a JSON file.
+func loadAuthenticator(path string) (*authenticator, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config authConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parse auth config %s: %w", path, err)
+	}
+
+	a := &authenticator{
+		issuer:     config.Issuer,
+		audience:   config.Audience,
+		hmacSecret: []byte(config.HMACSecret),
+		jwks:       make(map[string]crypto.PublicKey),
+		apiKeys:    make(map[[sha256.Size]byte]*identity),
+		now:        time.Now,
+	}
+	if len(a.hmacSecret) > 0 && len(a.hmacSecret) < 32 {
+		return nil, fmt.Errorf("auth config %s: hmac_secret must be at least 32 bytes", path)
+	}
+	if config.JWKSFile != "" {
+		jwksPath := config.JWKSFile
+		if !filepath.IsAbs(jwksPath) {
+			jwksPath = filepath.Join(filepath.Dir(path), jwksPath)
+		}
+		if a.jwks, err = loadJWKS(jwksPath); err != nil {
+			return nil, err
+		}
+	}
+	for i, k := range config.APIKeys {
+		if k.Name == "" || k.Key == "" {
+			return nil, fmt.Errorf("auth config %s: api_keys[%d] needs a name and a key", path, i)
+		}
+		digest := sha256.Sum256([]byte(k.Key))
+		if _, dup := a.apiKeys[digest]; dup {
+			return nil, fmt.Errorf("auth config %s: api key %q is listed more than once", path, k.Name)
+		}
+		a.apiKeys[digest] = &identity{subject: k.Name, email: k.Email, roles: k.Roles, via: authViaAPIKey}
+	}
+	if len(a.hmacSecret) == 0 && len(a.jwks) == 0 && len(a.apiKeys) == 0 {
+		return nil, fmt.Errorf("auth config %s: no hmac_secret, jwks_file or api_keys to authenticate with", path)
+	}
+	return a, nil
+}
+
+// unaryInterceptor rejects calls without valid credentials and attaches the
+// caller to the context of the rest.
+func (a *authenticator) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// streamInterceptor is unaryInterceptor for streaming calls.
+func (a *authenticator) streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := a.authenticate(stream.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+}
+
+// authenticatedStream is a server stream whose context carries the caller.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context { return s.ctx }
+
+// authenticate checks the credentials in the metadata of ctx and returns ctx
+// carrying the caller. It fails with UNAUTHENTICATED.
+func (a *authenticator) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if keys := md.Get("x-api-key"); len(keys) > 0 {
+		if id, ok := a.apiKeys[sha256.Sum256([]byte(keys[0]))]; ok {
+			return withIdentity(ctx, id), nil
+		}
+		return nil, errorInfo(codes.Unauthenticated, reasonCredentialsInvalid, nil, "unknown API key")
+	}
+
+	auth := md.Get("authorization")
+	if len(auth) == 0 {
+		return nil, errorInfo(codes.Unauthenticated, reasonCredentialsMissing, nil,
+			"credentials required: send authorization: Bearer <token> or x-api-key metadata")
+	}
+	scheme, token, ok := strings.Cut(auth[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") || token == "" {
+		return nil, errorInfo(codes.Unauthenticated, reasonCredentialsInvalid, nil, "authorization must be a Bearer token")
+	}
+	if id, ok := a.apiKeys[sha256.Sum256([]byte(token))]; ok {
+		return withIdentity(ctx, id), nil
+	}
+	id, err := a.verifyToken(token)
+	if err != nil {
+		return nil, errorInfo(codes.Unauthenticated, reasonCredentialsInvalid, nil, "invalid token: %v", err)
+	}
+	return withIdentity(ctx, id), nil
+}
+
+// tokenClaims are the claims read from a JWT.
+type tokenClaims struct {
+	Email string   `json:"email"`
+	Roles []string `json:"roles"`
+	jwt.RegisteredClaims
+}
+
+// verifyToken checks the signature, expiry, issuer and audience of a JWT and
+// returns its caller. Tokens must expire and name their subject.
+func (a *authenticator) verifyToken(token string) (*identity, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(tokenLeeway),
+		jwt.WithTimeFunc(a.now),
+	}
+	if a.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(a.issuer))
+	}
+	if a.audience != "" {
+		opts = append(opts, jwt.WithAudience(a.audience))
+	}
+	claims := &tokenClaims{}
+	if _, err := jwt.ParseWithClaims(token, claims, a.tokenKey, opts...); err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("token has no subject")
+	}
+	return &identity{subject: claims.Subject, email: claims.Email, roles: claims.Roles, via: authViaJWT}, nil
+}
+
+// tokenKey returns the key that verifies token: the HMAC secret for HS*
+// tokens, and otherwise the JWKS key named by its kid header. The JWT library
+// rejects keys of the wrong type for the token's algorithm.
+func (a *authenticator) tokenKey(token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if len(a.hmacSecret) == 0 {
+			return nil, fmt.Errorf("HMAC tokens are not accepted")
+		}
+		return a.hmacSecret, nil
+	}
+	kid, _ := token.Header["kid"].(string)
+	if key, ok := a.jwks[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown key %q", kid)
+}
+
+// jwk is one key of a JSON Web Key Set. Only public keys are read.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// loadJWKS reads the RSA, EC and Ed25519 public keys of a JWKS file by key ID.
+func loadJWKS(path string) (map[string]crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("parse JWKS %s: %w", path, err)
+	}
+	keys := make(map[string]crypto.PublicKey)
+	for i, k := range set.Keys {
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("JWKS %s: keys[%d]: %w", path, i, err)
+		}
+		if _, dup := keys[k.Kid]; dup {
+			return nil, fmt.Errorf("JWKS %s: key ID %q is used more than once", path, k.Kid)
+		}
+		keys[k.Kid] = key
+	}
+	return keys, nil
+}
+
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := jwkInt(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("n: %w", err)
+		}
+		e, err := jwkInt(k.E)
+		if err != nil || !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("invalid exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		curves := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}
+		curve, ok := curves[k.Crv]
+		if !ok {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := jwkInt(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("x: %w", err)
+		}
+		y, err := jwkInt(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("y: %w", err)
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("point is not on curve %s", k.Crv)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
+
+// jwkInt decodes a base64url big-endian integer.
+func jwkInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, fmt.Errorf("invalid base64url integer")
+	}
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testHMACSecret = "0123456789abcdef0123456789abcdef"
+
+// writeAuthConfig writes an auth config accepting HMAC tokens, tokens signed
+// by the returned Ed25519 key, and the API key "agent-key".
+func writeAuthConfig(t *testing.T) (string, ed25519.PrivateKey) {
+	dir := t.TempDir()
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	jwks := `{"keys": [{"kty": "OKP", "crv": "Ed25519", "kid": "k1", "x": "` + base64.RawURLEncoding.EncodeToString(public) + `"}]}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "jwks.json"), []byte(jwks), 0o600))
+	config := `{
+		"issuer": "https://tickets.example.com",
+		"audience": "ticket-service",
+		"hmac_secret": "` + testHMACSecret + `",
+		"jwks_file": "jwks.json",
+		"api_keys": [{"name": "station-lon", "key": "agent-key", "roles": ["agent"]}]
+	}`
+	path := filepath.Join(dir, "auth.json")
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+	return path, private
+}
+
+// signToken returns a token for john.doe that lasts an hour from now, after
+// edit has changed its claims.
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, now time.Time, edit func(*tokenClaims)) string {
+	claims := &tokenClaims{
+		Email: "john.doe@example.com",
+		Roles: []string{rolePassenger},
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-42",
+			Issuer:    "https://tickets.example.com",
+			Audience:  jwt.ClaimStrings{"ticket-service"},
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+	}
+	if edit != nil {
+		edit(claims)
+	}
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = "k1"
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestAuthenticate(t *testing.T) {
+	path, private := writeAuthConfig(t)
+	a, err := loadAuthenticator(path)
+	require.NoError(t, err)
+	now := time.Date(2030, 8, 1, 12, 0, 0, 0, time.UTC)
+	a.now = func() time.Time { return now }
+
+	hmacToken := signToken(t, jwt.SigningMethodHS256, []byte(testHMACSecret), now, nil)
+	tests := []struct {
+		name     string
+		md       metadata.MD
+		expected *identity
+		reason   string
+	}{
+		{"hmac token", metadata.Pairs("authorization", "Bearer "+hmacToken), &identity{subject: "user-42", email: "john.doe@example.com", roles: []string{rolePassenger}, via: authViaJWT}, ""},
+		{"jwks token", metadata.Pairs("authorization", "bearer "+signToken(t, jwt.SigningMethodEdDSA, private, now, nil)), &identity{subject: "user-42", email: "john.doe@example.com", roles: []string{rolePassenger}, via: authViaJWT}, ""},
+		{"api key header", metadata.Pairs("x-api-key", "agent-key"), &identity{subject: "station-lon", roles: []string{roleAgent}, via: authViaAPIKey}, ""},
+		{"api key as bearer", metadata.Pairs("authorization", "Bearer agent-key"), &identity{subject: "station-lon", roles: []string{roleAgent}, via: authViaAPIKey}, ""},
+		{"no credentials", metadata.MD{}, nil, reasonCredentialsMissing},
+		{"unknown api key", metadata.Pairs("x-api-key", "guess"), nil, reasonCredentialsInvalid},
+		{"basic auth", metadata.Pairs("authorization", "Basic am9objpwdw=="), nil, reasonCredentialsInvalid},
+		{"wrong secret", metadata.Pairs("authorization", "Bearer "+signToken(t, jwt.SigningMethodHS256, []byte("another-secret-another-secret-00"), now, nil)), nil, reasonCredentialsInvalid},
+		{"expired", metadata.Pairs("authorization", "Bearer "+signToken(t, jwt.SigningMethodHS256, []byte(testHMACSecret), now, func(c *tokenClaims) {
+			c.ExpiresAt = jwt.NewNumericDate(now.Add(-time.Minute))
+		})), nil, reasonCredentialsInvalid},
+		{"no expiry", metadata.Pairs("authorization", "Bearer "+signToken(t, jwt.SigningMethodHS256, []byte(testHMACSecret), now, func(c *tokenClaims) {
+			c.ExpiresAt = nil
+		})), nil, reasonCredentialsInvalid},
+		{"wrong audience", metadata.Pairs("authorization", "Bearer "+signToken(t, jwt.SigningMethodHS256, []byte(testHMACSecret), now, func(c *tokenClaims) {
+			c.Audience = jwt.ClaimStrings{"billing"}
+		})), nil, reasonCredentialsInvalid},
+		{"no subject", metadata.Pairs("authorization", "Bearer "+signToken(t, jwt.SigningMethodHS256, []byte(testHMACSecret), now, func(c *tokenClaims) {
+			c.Subject = ""
+		})), nil, reasonCredentialsInvalid},
+		{"unsigned", metadata.Pairs("authorization", "Bearer "+signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, now, nil)), nil, reasonCredentialsInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := a.authenticate(metadata.NewIncomingContext(context.Background(), tt.md))
+			if tt.reason != "" {
+				_, reason := errorDetails(err)
+				assert.Equal(t, codes.Unauthenticated, status.Code(err))
+				assert.Equal(t, tt.reason, reason)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, callerIdentity(ctx))
+		})
+	}
+}
+
+func TestAuthInterceptors(t *testing.T) {
+	path, _ := writeAuthConfig(t)
+	a, err := loadAuthenticator(path)
+	require.NoError(t, err)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "agent-key"))
+
+	var caller *identity
+	_, err = a.unaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		caller = callerIdentity(ctx)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "station-lon", caller.subject)
+
+	stream := &seatMapStream{ctx: ctx}
+	err = a.streamInterceptor(nil, stream, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		caller = callerIdentity(stream.Context())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "station-lon", caller.subject)
+
+	// Calls without credentials never reach the handler
+	stream = &seatMapStream{ctx: context.Background()}
+	err = a.streamInterceptor(nil, stream, &grpc.StreamServerInfo{}, func(srv interface{}, stream grpc.ServerStream) error {
+		t.Fatal("handler called")
+		return nil
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Nil(t, callerIdentity(context.Background()))
+}
+
+func TestLoadAuthenticator(t *testing.T) {
+	dir := t.TempDir()
+	for name, config := range map[string]interface{}{
+		"empty":        map[string]interface{}{},
+		"short secret": map[string]interface{}{"hmac_secret": "secret"},
+		"unnamed key":  map[string]interface{}{"api_keys": []map[string]string{{"key": "k"}}},
+		"repeated key": map[string]interface{}{"api_keys": []map[string]string{{"name": "a", "key": "k"}, {"name": "b", "key": "k"}}},
+		"missing jwks": map[string]interface{}{"jwks_file": "missing.json"},
+	} {
+		data, err := json.Marshal(config)
+		require.NoError(t, err)
+		path := filepath.Join(dir, "auth.json")
+		require.NoError(t, os.WriteFile(path, data, 0o600))
+		_, err = loadAuthenticator(path)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"time"
+
+	"github.com/Aravinthvvs/gRPC/boardingpass"
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Bounds on the width of boarding pass QR codes, in pixels.
+const (
+	defaultQRSize = 256
+	maxQRSize     = 2048
+)
+
+func (s *server) GetBoardingPass(ctx context.Context, req *pb.GetBoardingPassRequest) (*pb.BoardingPass, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if req.ReceiptId == "" {
+		violations = append(violations, fieldViolation("receipt_id", "receipt_id is required"))
+	}
+	if req.QrSize < 0 || req.QrSize > maxQRSize {
+		violations = append(violations, fieldViolation("qr_size", "qr_size must be between 0 and 2048"))
+	}
+	if len(violations) > 0 {
+		return nil, badRequest(violations...)
+	}
+	receipt, err := s.resolveTicket(req.ReceiptId, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := bookingCancelled(receipt); err != nil {
+		return nil, err
+	}
+	line, err := seatLineFor(receipt, "seat", req.Seat)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign the pass and draw it
+	token, err := boardingpass.Sign(s.passKey, boardingpass.Pass{
+		ReceiptID:   receipt.ReceiptId,
+		DepartureID: receipt.DepartureId,
+		Seat:        line.Seat,
+		Passenger:   line.Passenger.GetEmail(),
+	})
+	if err != nil {
+		return nil, internalError(err)
+	}
+	size := int(req.QrSize)
+	if size == 0 {
+		size = defaultQRSize
+	}
+	png, err := boardingpass.QRCode(token, size)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	text, err := boardingpass.QRText(token)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	pass := &pb.BoardingPass{
+		ReceiptId:   receipt.ReceiptId,
+		DepartureId: receipt.DepartureId,
+		Passenger:   line.Passenger,
+		Section:     line.Section,
+		Seat:        line.Seat,
+		Token:       token,
+		QrPng:       png,
+		QrText:      text,
+	}
+	if from, ok := s.catalog.station(receipt.From); ok {
+		pass.From = from.toProto()
+	}
+	if to, ok := s.catalog.station(receipt.To); ok {
+		pass.To = to.toProto()
+	}
+	if boards := s.boardingTime(receipt); !boards.IsZero() {
+		pass.BoardsAt = timestamppb.New(boards)
+	}
+	return pass, nil
+}
+
+// ValidateTicket checks a passenger in. The token must be signed by this
+// server, for departure_id, and name a passenger who still holds that seat on
+// a live booking; each passenger is checked in once.
+func (s *server) ValidateTicket(ctx context.Context, req *pb.ValidateTicketRequest) (*pb.ValidateTicketResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if req.Token == "" {
+		violations = append(violations, fieldViolation("token", "token is required"))
+	}
+	if req.DepartureId == "" {
+		violations = append(violations, fieldViolation("departure_id", "departure_id is required"))
+	}
+	if len(violations) > 0 {
+		return nil, badRequest(violations...)
+	}
+	pass, err := boardingpass.Verify(s.passKey.Public().(ed25519.PublicKey), req.Token)
+	if err != nil {
+		return nil, errorInfo(codes.InvalidArgument, reasonInvalidBoardingPass, nil, "invalid boarding pass: %v", err)
+	}
+	if pass.DepartureID != req.DepartureId {
+		return nil, errorInfo(codes.FailedPrecondition, reasonWrongDeparture,
+			map[string]string{"receipt_id": pass.ReceiptID, "departure_id": pass.DepartureID},
+			"boarding pass is for departure %s", pass.DepartureID)
+	}
+
+	// The pass must still match the booking
+	receipt, err := s.resolveTicket(pass.ReceiptID, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := bookingCancelled(receipt); err != nil {
+		return nil, err
+	}
+	var line *pb.SeatLine
+	for _, l := range seatLines(receipt) {
+		if l.Seat == pass.Seat && l.Passenger.GetEmail() == pass.Passenger {
+			line = l
+		}
+	}
+	if line == nil {
+		return nil, errorInfo(codes.FailedPrecondition, reasonBoardingPassOutdated,
+			map[string]string{"receipt_id": receipt.ReceiptId, "seat": pass.Seat},
+			"%s no longer holds seat %s on %s, a new boarding pass is needed", pass.Passenger, pass.Seat, receipt.ReceiptId)
+	}
+	if line.CheckedInAt != nil {
+		checkedIn := line.CheckedInAt.AsTime().Format(time.RFC3339)
+		return nil, errorInfo(codes.FailedPrecondition, reasonAlreadyCheckedIn,
+			map[string]string{"receipt_id": receipt.ReceiptId, "seat": line.Seat, "checked_in_at": checkedIn},
+			"%s already boarded at %s", pass.Passenger, checkedIn)
+	}
+
+	line.CheckedInAt = timestamppb.New(s.now())
+	if err := s.saveTicket(receipt); err != nil {
+		return nil, storeError(err)
+	}
+
+	return &pb.ValidateTicketResponse{
+		ReceiptId:   receipt.ReceiptId,
+		Passenger:   line.Passenger,
+		Section:     line.Section,
+		Seat:        line.Seat,
+		CheckedInAt: line.CheckedInAt,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"testing"
+	"time"
+
+	"github.com/Aravinthvvs/gRPC/boardingpass"
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetBoardingPass(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	adult := &pb.User{Email: "jane.doe@example.com"}
+	child := &pb.User{Email: "kid.doe@example.com", PassengerType: pb.PassengerType_PASSENGER_TYPE_CHILD}
+	resp, err := s.PurchaseTicket(ctx, &pb.PurchaseRequest{
+		From:        "London",
+		To:          "Paris",
+		DepartureId: testDepartureID,
+		User:        adult,
+		Passengers:  []*pb.User{adult, child},
+	})
+	require.NoError(t, err)
+	receipt, err := s.GetReceipt(ctx, &pb.ReceiptRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+	childSeat := receipt.SeatLines[1].Seat
+
+	// A party ticket needs the seat to pick the passenger
+	_, err = s.GetBoardingPass(ctx, &pb.GetBoardingPassRequest{ReceiptId: resp.ReceiptId})
+	fields, _ := errorDetails(err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, []string{"seat"}, fields)
+
+	pass, err := s.GetBoardingPass(ctx, &pb.GetBoardingPassRequest{ReceiptId: resp.ReceiptId, Seat: childSeat})
+	require.NoError(t, err)
+	assert.Equal(t, child.Email, pass.Passenger.Email)
+	assert.Equal(t, childSeat, pass.Seat)
+	assert.Equal(t, "London", pass.From.Name)
+	assert.Equal(t, s.boardingTime(receipt), pass.BoardsAt.AsTime())
+	assert.True(t, bytes.HasPrefix(pass.QrPng, []byte("\x89PNG")))
+	assert.NotEmpty(t, pass.QrText)
+
+	// The token checks out offline against the server's public key
+	verified, err := boardingpass.Verify(s.passKey.Public().(ed25519.PublicKey), pass.Token)
+	require.NoError(t, err)
+	assert.Equal(t, boardingpass.Pass{ReceiptID: resp.ReceiptId, DepartureID: testDepartureID, Seat: childSeat, Passenger: child.Email}, *verified)
+
+	// Cancelled tickets get no pass
+	_, err = s.CancelTicket(ctx, &pb.CancelTicketRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+	_, err = s.GetBoardingPass(ctx, &pb.GetBoardingPassRequest{ReceiptId: resp.ReceiptId, Seat: childSeat})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = s.GetBoardingPass(ctx, &pb.GetBoardingPassRequest{ReceiptId: "rec-404"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestValidateTicket(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	now := time.Date(2030, 9, 1, 7, 45, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	resp, err := purchaseWithToken(s, "john.doe@example.com", "A1", "")
+	require.NoError(t, err)
+	pass, err := s.GetBoardingPass(ctx, &pb.GetBoardingPassRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+
+	// Forged and misdirected passes are turned away
+	forged, err := boardingpass.Sign(ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize)), boardingpass.Pass{
+		ReceiptID: resp.ReceiptId, DepartureID: testDepartureID, Seat: "A1", Passenger: "john.doe@example.com",
+	})
+	require.NoError(t, err)
+	_, err = s.ValidateTicket(ctx, &pb.ValidateTicketRequest{Token: forged, DepartureId: testDepartureID})
+	_, reason := errorDetails(err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, reasonInvalidBoardingPass, reason)
+	_, err = s.ValidateTicket(ctx, &pb.ValidateTicketRequest{Token: pass.Token, DepartureId: "ES9024-20300901"})
+	_, reason = errorDetails(err)
+	assert.Equal(t, reasonWrongDeparture, reason)
+
+	checkIn, err := s.ValidateTicket(ctx, &pb.ValidateTicketRequest{Token: pass.Token, DepartureId: testDepartureID})
+	require.NoError(t, err)
+	assert.Equal(t, "A1", checkIn.Seat)
+	assert.Equal(t, now, checkIn.CheckedInAt.AsTime())
+	receipt, err := s.GetReceipt(ctx, &pb.ReceiptRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+	assert.Equal(t, now, receipt.SeatLines[0].CheckedInAt.AsTime())
+
+	// The same pass cannot board twice
+	_, err = s.ValidateTicket(ctx, &pb.ValidateTicketRequest{Token: pass.Token, DepartureId: testDepartureID})
+	_, reason = errorDetails(err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, reasonAlreadyCheckedIn, reason)
+}
+
+func TestValidateTicketOutdated(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	resp, err := purchaseWithToken(s, "john.doe@example.com", "A1", "")
+	require.NoError(t, err)
+	pass, err := s.GetBoardingPass(ctx, &pb.GetBoardingPassRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+
+	// Moving seat needs a new pass
+	_, err = s.ModifySeat(ctx, &pb.ModifySeatRequest{ReceiptId: resp.ReceiptId, NewSeat: "B3"})
+	require.NoError(t, err)
+	_, err = s.ValidateTicket(ctx, &pb.ValidateTicketRequest{Token: pass.Token, DepartureId: testDepartureID})
+	_, reason := errorDetails(err)
+	assert.Equal(t, reasonBoardingPassOutdated, reason)
+
+	// Cancelled bookings cannot board at all
+	pass, err = s.GetBoardingPass(ctx, &pb.GetBoardingPassRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+	_, err = s.CancelTicket(ctx, &pb.CancelTicketRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+	_, err = s.ValidateTicket(ctx, &pb.ValidateTicketRequest{Token: pass.Token, DepartureId: testDepartureID})
+	_, reason = errorDetails(err)
+	assert.Equal(t, reasonBookingCancelled, reason)
+}
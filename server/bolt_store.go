@@ -0,0 +1,238 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	receiptsBucket     = []byte("receipts")
+	userReceiptsBucket = []byte("userReceipts")
+	sectionSeatsBucket = []byte("sectionSeats")
+	seatMapsBucket     = []byte("seatMaps")
+
+	sectionNames = []string{"SectionA", "SectionB"}
+)
+
+// boltStore keeps bookings in a bbolt database file so they survive restarts.
+type boltStore struct {
+	db *bolt.DB
+}
+
+// openBoltStore opens (creating if needed) the database file at path.
+func openBoltStore(path string) (*boltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{receiptsBucket, userReceiptsBucket, seatMapsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		sections, err := tx.CreateBucketIfNotExists(sectionSeatsBucket)
+		if err != nil {
+			return err
+		}
+		for _, section := range sectionNames {
+			if _, err := sections.CreateBucketIfNotExists([]byte(section)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &boltStore{db: db}, nil
+}
+
+func (b *boltStore) SaveReceipt(receiptID string, receipt *pb.ReceiptResponse) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return saveReceipt(tx, receiptID, receipt)
+	})
+}
+
+func (b *boltStore) Receipt(receiptID string) (*pb.ReceiptResponse, error) {
+	receipt := &pb.ReceiptResponse{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(receiptsBucket).Get([]byte(receiptID))
+		if data == nil {
+			return errNotFound
+		}
+		return proto.Unmarshal(data, receipt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+func (b *boltStore) ReceiptIDsForEmail(email string) ([]string, error) {
+	var receiptIDs []string
+	err := b.db.View(func(tx *bolt.Tx) error {
+		userReceipts := tx.Bucket(userReceiptsBucket).Bucket([]byte(email))
+		if userReceipts == nil {
+			return nil
+		}
+		return userReceipts.ForEach(func(k, _ []byte) error {
+			receiptIDs = append(receiptIDs, string(k))
+			return nil
+		})
+	})
+	return receiptIDs, err
+}
+
+// SaveTicket writes the receipt, seat map and section index in a single
+// transaction, so a failure part way leaves none of them changed.
+func (b *boltStore) SaveTicket(receipt *pb.ReceiptResponse, released []string) error {
+	receiptID := receipt.ReceiptId
+	return b.db.Update(func(tx *bolt.Tx) error {
+		if err := saveReceipt(tx, receiptID, receipt); err != nil {
+			return err
+		}
+		seats, err := tx.Bucket(seatMapsBucket).CreateBucketIfNotExists([]byte(receipt.DepartureId))
+		if err != nil {
+			return err
+		}
+		for _, seat := range released {
+			if err := seats.Delete([]byte(seat)); err != nil {
+				return err
+			}
+		}
+		if err := deleteSectionSeats(tx, receiptID); err != nil {
+			return err
+		}
+		bySection := make(map[string][]string)
+		for _, line := range receipt.SeatLines {
+			if err := seats.Put([]byte(line.Seat), []byte(receiptID)); err != nil {
+				return err
+			}
+			bySection[line.Section] = append(bySection[line.Section], line.Seat)
+		}
+		for section, ids := range bySection {
+			bucket := tx.Bucket(sectionSeatsBucket).Bucket([]byte(section))
+			if bucket == nil {
+				return errNotFound
+			}
+			sort.Strings(ids)
+			if err := bucket.Put([]byte(receiptID), []byte(strings.Join(ids, ","))); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (b *boltStore) SectionSeats(section string) (map[string][]string, error) {
+	seats := make(map[string][]string)
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(sectionSeatsBucket).Bucket([]byte(section))
+		if bucket == nil {
+			return errNotFound
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			seats[string(k)] = strings.Split(string(v), ",")
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return seats, nil
+}
+
+func (b *boltStore) OccupiedSeats(departureID string) (map[string]string, error) {
+	seats := make(map[string]string)
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(seatMapsBucket).Bucket([]byte(departureID))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			seats[string(k)] = string(v)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return seats, nil
+}
+
+func (b *boltStore) Close() error {
+	return b.db.Close()
+}
+
+// saveReceipt stores receipt under receiptID and indexes it under the emails
+// of everyone on it.
+func saveReceipt(tx *bolt.Tx, receiptID string, receipt *pb.ReceiptResponse) error {
+	data, err := proto.Marshal(receipt)
+	if err != nil {
+		return err
+	}
+	if err := unindexReceipt(tx, receiptID); err != nil {
+		return err
+	}
+	if err := tx.Bucket(receiptsBucket).Put([]byte(receiptID), data); err != nil {
+		return err
+	}
+	for _, email := range receiptEmails(receipt) {
+		userReceipts, err := tx.Bucket(userReceiptsBucket).CreateBucketIfNotExists([]byte(email))
+		if err != nil {
+			return err
+		}
+		if err := userReceipts.Put([]byte(receiptID), nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unindexReceipt drops receiptID from the per-user index of its current owner.
+func unindexReceipt(tx *bolt.Tx, receiptID string) error {
+	data := tx.Bucket(receiptsBucket).Get([]byte(receiptID))
+	if data == nil {
+		return nil
+	}
+	old := &pb.ReceiptResponse{}
+	if err := proto.Unmarshal(data, old); err != nil {
+		return err
+	}
+	users := tx.Bucket(userReceiptsBucket)
+	for _, email := range receiptEmails(old) {
+		userReceipts := users.Bucket([]byte(email))
+		if userReceipts == nil {
+			continue
+		}
+		if err := userReceipts.Delete([]byte(receiptID)); err != nil {
+			return err
+		}
+		if k, _ := userReceipts.Cursor().First(); k == nil {
+			if err := users.DeleteBucket([]byte(email)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteSectionSeats removes receiptID from every section bucket.
+func deleteSectionSeats(tx *bolt.Tx, receiptID string) error {
+	sections := tx.Bucket(sectionSeatsBucket)
+	for _, name := range sectionNames {
+		if err := sections.Bucket([]byte(name)).Delete([]byte(receiptID)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
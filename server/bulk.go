@@ -0,0 +1,54 @@
+package main
+
+import (
+	"io"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+)
+
+// BulkPurchase buys the tickets streamed to it one at a time, in the order
+// they arrive, so rows competing for the same seat are settled as they would
+// be if bought one after another.
+func (s *server) BulkPurchase(stream pb.TicketService_BulkPurchaseServer) error {
+	resp := &pb.BulkPurchaseResponse{}
+	for row := int32(1); ; row++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(resp)
+		}
+		if err != nil {
+			return err
+		}
+
+		result := &pb.BulkPurchaseResult{Row: row}
+		purchase, err := s.PurchaseTicket(stream.Context(), req)
+		if err != nil {
+			result.Error = bulkPurchaseError(err)
+			resp.Failed++
+		} else {
+			result.ReceiptId = purchase.ReceiptId
+			resp.Succeeded++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+}
+
+// bulkPurchaseError flattens the status returned by a purchase into a row of
+// a bulk purchase summary.
+func bulkPurchaseError(err error) *pb.BulkPurchaseError {
+	st := status.Convert(err)
+	e := &pb.BulkPurchaseError{Code: st.Code().String(), Message: st.Message()}
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.ErrorInfo:
+			e.Reason = d.Reason
+		case *errdetails.BadRequest:
+			for _, v := range d.FieldViolations {
+				e.Fields = append(e.Fields, v.Field)
+			}
+		}
+	}
+	return e
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// bulkPurchaseStream feeds requests to BulkPurchase and keeps its summary.
+type bulkPurchaseStream struct {
+	grpc.ServerStream
+	requests []*pb.PurchaseRequest
+	resp     *pb.BulkPurchaseResponse
+}
+
+func (b *bulkPurchaseStream) Context() context.Context { return context.Background() }
+
+func (b *bulkPurchaseStream) Recv() (*pb.PurchaseRequest, error) {
+	if len(b.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := b.requests[0]
+	b.requests = b.requests[1:]
+	return req, nil
+}
+
+func (b *bulkPurchaseStream) SendAndClose(resp *pb.BulkPurchaseResponse) error {
+	b.resp = resp
+	return nil
+}
+
+func TestBulkPurchase(t *testing.T) {
+	s := newTestServer()
+	request := func(email, seat, token string) *pb.PurchaseRequest {
+		return &pb.PurchaseRequest{From: "London", To: "Paris", DepartureId: testDepartureID, User: &pb.User{Email: email}, PreferredSeat: seat, PaymentToken: token}
+	}
+	stream := &bulkPurchaseStream{requests: []*pb.PurchaseRequest{
+		request("ann@example.com", "A1", ""),
+		request("bob@example.com", "A1", ""),
+		request("", "A2", ""),
+		request("cat@example.com", "A3", fakeTokenDecline),
+		request("dan@example.com", "A3", ""),
+	}}
+	require.NoError(t, s.BulkPurchase(stream))
+
+	resp := stream.resp
+	assert.Equal(t, int32(2), resp.Succeeded)
+	assert.Equal(t, int32(3), resp.Failed)
+	require.Len(t, resp.Results, 5)
+	for i, result := range resp.Results {
+		assert.Equal(t, int32(i+1), result.Row)
+	}
+	assert.Equal(t, "rec-1", resp.Results[0].ReceiptId)
+	assert.Equal(t, &pb.BulkPurchaseError{Code: "FailedPrecondition", Message: resp.Results[1].Error.GetMessage(), Reason: reasonSeatTaken}, resp.Results[1].Error)
+	assert.Equal(t, "InvalidArgument", resp.Results[2].Error.GetCode())
+	assert.Equal(t, []string{"user.email"}, resp.Results[2].Error.GetFields())
+	assert.Equal(t, reasonPaymentDeclined, resp.Results[3].Error.GetReason())
+	// The declined row's seat went to the next row that asked for it
+	assert.Empty(t, resp.Results[4].Error)
+	receipt, err := s.GetReceipt(context.Background(), &pb.ReceiptRequest{ReceiptId: resp.Results[4].ReceiptId})
+	require.NoError(t, err)
+	assert.Equal(t, "A3", receipt.Seat)
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/Aravinthvvs/gRPC/money"
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Refund rules applied by a cancellationPolicy.
+const (
+	refundRuleFull           = "full"
+	refundRulePartial        = "partial"
+	refundRuleAfterDeparture = "after_departure"
+)
+
+// cancellationPolicy decides how much of the fare is refunded when seats are
+// cancelled: all of it up to FullRefundHours before the train leaves the
+// passenger's boarding station, PartialRefundPercent of it after that, and
+// nothing once the train has left.
+type cancellationPolicy struct {
+	FullRefundHours      int `json:"full_refund_hours"`
+	PartialRefundPercent int `json:"partial_refund_percent"`
+}
+
+// defaultCancellationPolicy returns the built-in policy.
+func defaultCancellationPolicy() *cancellationPolicy {
+	return &cancellationPolicy{FullRefundHours: 48, PartialRefundPercent: 50}
+}
+
+// loadCancellationPolicy reads a cancellation policy from a JSON file.
+func loadCancellationPolicy(path string) (*cancellationPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := &cancellationPolicy{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("parse cancellation policy %s: %w", path, err)
+	}
+	if p.FullRefundHours < 0 {
+		return nil, fmt.Errorf("cancellation policy %s: full_refund_hours must not be negative", path)
+	}
+	if p.PartialRefundPercent < 0 || p.PartialRefundPercent > 100 {
+		return nil, fmt.Errorf("cancellation policy %s: partial_refund_percent must be between 0 and 100", path)
+	}
+	return p, nil
+}
+
+// refund returns the share of the fare refunded, in percent, for a journey
+// boarding at boards and cancelled at now, and the rule that applied.
+func (p *cancellationPolicy) refund(boards, now time.Time) (int, string) {
+	switch {
+	case now.Before(boards.Add(-time.Duration(p.FullRefundHours) * time.Hour)):
+		return 100, refundRuleFull
+	case now.Before(boards):
+		return p.PartialRefundPercent, refundRulePartial
+	}
+	return 0, refundRuleAfterDeparture
+}
+
+// bookingCancelled fails with FAILED_PRECONDITION if receipt has been cancelled.
+func bookingCancelled(receipt *pb.ReceiptResponse) error {
+	if receipt.Status != pb.BookingStatus_BOOKING_STATUS_CANCELLED {
+		return nil
+	}
+	return errorInfo(codes.FailedPrecondition, reasonBookingCancelled, map[string]string{"receipt_id": receipt.ReceiptId},
+		"booking %s has been cancelled", receipt.ReceiptId)
+}
+
+// notBoarded fails with FAILED_PRECONDITION if a passenger on a line of
+// receipt that matches has been checked in: their seat has been used and can
+// no longer be given up or refunded.
+func notBoarded(receipt *pb.ReceiptResponse, match func(*pb.SeatLine) bool) error {
+	for _, line := range seatLines(receipt) {
+		if match(line) && line.CheckedInAt != nil {
+			checkedIn := line.CheckedInAt.AsTime().Format(time.RFC3339)
+			return errorInfo(codes.FailedPrecondition, reasonAlreadyCheckedIn,
+				map[string]string{"receipt_id": receipt.ReceiptId, "seat": line.Seat, "checked_in_at": checkedIn},
+				"%s boarded at %s, seat %s can no longer be cancelled", line.Passenger.GetEmail(), checkedIn, line.Seat)
+		}
+	}
+	return nil
+}
+
+// cancelSeats gives up the seats on receipt whose lines match, refunds their
+// fares under the cancellation policy and records the refund on the receipt,
+// which is cancelled once no seats remain. The freed seats are offered to the
+// departure's waitlist. It returns nil if no line matched, and refuses to
+// cancel anything if a matching passenger has boarded.
+//
+// The server lock must be held. It is let go while the refund is paid: the
+// lines are marked cancelled first, so nobody else cancels them meanwhile,
+// and their seats are only freed once the money is back. A failed refund
+// puts the lines back as they were.
+func (s *server) cancelSeats(ctx context.Context, receipt *pb.ReceiptResponse, match func(*pb.SeatLine) bool) (*pb.Refund, error) {
+	if err := notBoarded(receipt, match); err != nil {
+		return nil, err
+	}
+	lines := seatLines(receipt)
+	var kept, cancelled []*pb.SeatLine
+	for _, line := range lines {
+		if match(line) {
+			cancelled = append(cancelled, line)
+		} else {
+			kept = append(kept, line)
+		}
+	}
+	if len(cancelled) == 0 {
+		return nil, nil
+	}
+
+	// Work out what is owed back
+	now := s.now()
+	percent, rule := s.cancellation.refund(s.boardingTime(receipt), now)
+	refund := &pb.Refund{
+		ReceiptId: receipt.ReceiptId,
+		Percent:   int32(percent),
+		Rule:      rule,
+		CreatedAt: timestamppb.New(now),
+	}
+	for _, line := range cancelled {
+		refund.PassengerEmails = append(refund.PassengerEmails, line.Passenger.GetEmail())
+	}
+	amount, err := refundAmount(receipt, refund.PassengerEmails, percent)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	refund.Amount = amount
+
+	// Mark the lines cancelled, keeping their seats taken
+	receipt.SeatLines = kept
+	receipt.CancelledSeatLines = append(receipt.CancelledSeatLines, cancelled...)
+	if len(kept) == 0 {
+		receipt.Status = pb.BookingStatus_BOOKING_STATUS_CANCELLED
+	}
+	if err := s.store.SaveReceipt(receipt.ReceiptId, receipt); err != nil {
+		return nil, storeError(err)
+	}
+
+	// Pay it back without holding up other calls
+	var refundErr error
+	if minor, _ := money.Minor(amount); minor > 0 && receipt.PaymentId != "" {
+		s.mu.Unlock()
+		refund.PaymentRefundId, refundErr = s.payRefund(ctx, receipt.PaymentId, amount)
+		s.mu.Lock()
+	}
+
+	// Other calls may have changed the receipt in the meantime
+	if receipt, err = s.store.Receipt(receipt.ReceiptId); err != nil {
+		return nil, storeError(err)
+	}
+	if refundErr != nil {
+		if err := s.restoreSeatLines(receipt, lines, cancelled); err != nil {
+			return nil, storeError(err)
+		}
+		return nil, refundErr
+	}
+
+	// Free the seats and keep the booking for the record
+	released := make([]string, len(cancelled))
+	for i, line := range cancelled {
+		released[i] = line.Seat
+	}
+	refund.RefundId = fmt.Sprintf("%s-refund-%d", receipt.ReceiptId, len(receipt.Refunds)+1)
+	receipt.Refunds = append(receipt.Refunds, refund)
+	if err := s.saveTicket(receipt, released...); err != nil {
+		return nil, storeError(err)
+	}
+	if err := s.promoteWaitlist(receipt.DepartureId); err != nil {
+		return nil, err
+	}
+	s.publishSeats(receipt.DepartureId)
+	return refund, nil
+}
+
+// payRefund returns amount of paymentID to the passenger and returns the
+// provider's refund ID.
+func (s *server) payRefund(ctx context.Context, paymentID string, amount *pb.Money) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.paymentTimeout)
+	defer cancel()
+	refundID, err := s.payments.Refund(ctx, paymentID, amount)
+	if err != nil {
+		return "", paymentError(ctx, err, "refund")
+	}
+	return refundID, nil
+}
+
+// restoreSeatLines moves cancelled back from the cancelled lines of receipt
+// after their refund failed, and confirms the booking again. lines are the
+// seat lines receipt had before, whose order is kept.
+func (s *server) restoreSeatLines(receipt *pb.ReceiptResponse, lines, cancelled []*pb.SeatLine) error {
+	restored := receipt.SeatLines
+	var still []*pb.SeatLine
+	for _, line := range receipt.CancelledSeatLines {
+		i := slices.IndexFunc(cancelled, func(c *pb.SeatLine) bool { return proto.Equal(c, line) })
+		if i < 0 {
+			still = append(still, line)
+			continue
+		}
+		restored = append(restored, line)
+		cancelled = slices.Delete(cancelled, i, i+1)
+	}
+	position := func(line *pb.SeatLine) int {
+		return slices.IndexFunc(lines, func(l *pb.SeatLine) bool { return l.Passenger.GetEmail() == line.Passenger.GetEmail() })
+	}
+	sort.SliceStable(restored, func(i, j int) bool { return position(restored[i]) < position(restored[j]) })
+
+	receipt.SeatLines = restored
+	receipt.CancelledSeatLines = still
+	receipt.Status = pb.BookingStatus_BOOKING_STATUS_CONFIRMED
+	return s.store.SaveReceipt(receipt.ReceiptId, receipt)
+}
+
+// refundAmount returns percent of the fares paid for emails on receipt,
+// rounded half up to the minor unit, or nil if receipt carries no fare.
+func refundAmount(receipt *pb.ReceiptResponse, emails []string, percent int) (*pb.Money, error) {
+	c, ok := money.Lookup(receipt.PricePaid.GetCurrencyCode())
+	if !ok {
+		return nil, nil
+	}
+	var paid int64
+	for _, line := range receipt.GetFare().GetLines() {
+		for _, email := range emails {
+			if line.PassengerEmail == email {
+				minor, err := money.Minor(line.Amount)
+				if err != nil {
+					return nil, err
+				}
+				paid += minor
+			}
+		}
+	}
+	return c.FromMinor((paid*int64(percent) + 50) / 100), nil
+}
+
+// boardingTime returns when the train leaves the station receipt's
+// passengers board at, or the zero time if the departure is unknown.
+func (s *server) boardingTime(receipt *pb.ReceiptResponse) time.Time {
+	dep, ok := s.catalog.departure(receipt.DepartureId)
+	if !ok {
+		return time.Time{}
+	}
+	from, fromOK := s.catalog.station(receipt.From)
+	to, toOK := s.catalog.station(receipt.To)
+	if fromOK && toOK {
+		if board, _, ok := dep.segment(from, to); ok {
+			return dep.stopTime(board)
+		}
+	}
+	return dep.stopTime(dep.Route.Stops[0])
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Aravinthvvs/gRPC/money"
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCancellationPolicyRefund(t *testing.T) {
+	p := defaultCancellationPolicy()
+	boards := time.Date(2030, 9, 1, 8, 1, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		now             time.Time
+		expectedPercent int
+		expectedRule    string
+	}{
+		{"days ahead", boards.Add(-72 * time.Hour), 100, refundRuleFull},
+		{"just inside the full refund window", boards.Add(-48*time.Hour - time.Minute), 100, refundRuleFull},
+		{"on the day", boards.Add(-2 * time.Hour), 50, refundRulePartial},
+		{"after departure", boards.Add(time.Minute), 0, refundRuleAfterDeparture},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			percent, rule := p.refund(boards, tt.now)
+			assert.Equal(t, tt.expectedPercent, percent)
+			assert.Equal(t, tt.expectedRule, rule)
+		})
+	}
+}
+
+func TestCancelTicketRefund(t *testing.T) {
+	s := newTestServer()
+	payments := newFakePaymentProvider()
+	s.payments = payments
+	ctx := context.Background()
+	adult := &pb.User{Email: "jane.doe@example.com"}
+	child := &pb.User{Email: "kid.doe@example.com", PassengerType: pb.PassengerType_PASSENGER_TYPE_CHILD}
+
+	// Booked on the morning of travel: £126 for the adult and £63 for the child.
+	s.now = func() time.Time { return time.Date(2030, 9, 1, 6, 0, 0, 0, time.UTC) }
+	resp, err := s.PurchaseTicket(ctx, &pb.PurchaseRequest{
+		From:        "London",
+		To:          "Paris",
+		DepartureId: testDepartureID,
+		User:        adult,
+		Passengers:  []*pb.User{adult, child},
+	})
+	require.NoError(t, err)
+
+	// Taking the child off the booking refunds half of their fare only.
+	removeResp, err := s.RemoveUser(ctx, &pb.RemoveUserRequest{Email: child.Email})
+	require.NoError(t, err)
+	require.Len(t, removeResp.Refunds, 1)
+	refund := removeResp.Refunds[0]
+	assert.Equal(t, resp.ReceiptId, refund.ReceiptId)
+	assert.Equal(t, []string{child.Email}, refund.PassengerEmails)
+	assert.Equal(t, refundRulePartial, refund.Rule)
+	assert.Equal(t, "£31.50", money.Format(refund.Amount))
+	assert.NotEmpty(t, refund.PaymentRefundId)
+
+	// Nothing is refunded once the train has left.
+	s.now = func() time.Time { return time.Date(2030, 9, 1, 8, 30, 0, 0, time.UTC) }
+	cancelResp, err := s.CancelTicket(ctx, &pb.CancelTicketRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+	assert.Equal(t, refundRuleAfterDeparture, cancelResp.Refund.Rule)
+	assert.Equal(t, "£0.00", money.Format(cancelResp.Refund.Amount))
+	assert.Empty(t, cancelResp.Refund.PaymentRefundId)
+	assert.Equal(t, int64(3150), payments.authorizations["auth-1"].refunded)
+
+	// The cancelled booking stays queryable with its refunds.
+	receipt, err := s.GetReceipt(ctx, &pb.ReceiptRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+	assert.Equal(t, pb.BookingStatus_BOOKING_STATUS_CANCELLED, receipt.Status)
+	assert.Empty(t, receipt.SeatLines)
+	assert.Len(t, receipt.CancelledSeatLines, 2)
+	assert.Len(t, receipt.Refunds, 2)
+	list, err := s.ListMyTickets(ctx, &pb.ListMyTicketsRequest{Email: child.Email})
+	require.NoError(t, err)
+	assert.Len(t, list.Receipts, 1)
+
+	_, err = s.ModifySeat(ctx, &pb.ModifySeatRequest{ReceiptId: resp.ReceiptId, NewSeat: "B1"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	dep, _ := s.catalog.departure(testDepartureID)
+	seats, err := s.seatMap(dep)
+	require.NoError(t, err)
+	assert.Empty(t, seats.occupied)
+}
+
+// failingRefundProvider approves payments but refuses every refund. If
+// started is set, it waits for release before refusing.
+type failingRefundProvider struct {
+	*fakePaymentProvider
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p failingRefundProvider) Refund(ctx context.Context, paymentID string, amount *pb.Money) (string, error) {
+	if p.started != nil {
+		p.started <- struct{}{}
+		<-p.release
+	}
+	return "", errors.New("gateway unavailable")
+}
+
+func TestCancelTicketRefundFails(t *testing.T) {
+	s := newTestServer()
+	payments := failingRefundProvider{newFakePaymentProvider(), make(chan struct{}, 1), make(chan struct{})}
+	s.payments = payments
+	ctx := context.Background()
+
+	resp, err := purchaseWithToken(s, "john.doe@example.com", "A1", "")
+	require.NoError(t, err)
+	done := make(chan error)
+	go func() {
+		_, err := s.CancelTicket(ctx, &pb.CancelTicketRequest{ReceiptId: resp.ReceiptId})
+		done <- err
+	}()
+	<-payments.started
+
+	// While the refund is paid the booking reads as cancelled, but its seat
+	// is not sold again
+	receipt, err := s.GetReceipt(ctx, &pb.ReceiptRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+	assert.Equal(t, pb.BookingStatus_BOOKING_STATUS_CANCELLED, receipt.Status)
+	_, err = s.CancelTicket(ctx, &pb.CancelTicketRequest{ReceiptId: resp.ReceiptId})
+	_, reason := errorDetails(err)
+	assert.Equal(t, reasonBookingCancelled, reason)
+	_, err = purchaseWithToken(s, "jane.doe@example.com", "A1", "")
+	_, reason = errorDetails(err)
+	assert.Equal(t, reasonSeatTaken, reason)
+
+	close(payments.release)
+	assert.Equal(t, codes.Unavailable, status.Code(<-done))
+
+	// The booking is rolled back and can be cancelled again later.
+	receipt, err = s.GetReceipt(ctx, &pb.ReceiptRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+	assert.Equal(t, pb.BookingStatus_BOOKING_STATUS_CONFIRMED, receipt.Status)
+	assert.Empty(t, receipt.Refunds)
+	assert.Empty(t, receipt.CancelledSeatLines)
+	assert.Equal(t, "A1", receipt.Seat)
+	require.Len(t, receipt.SeatLines, 1)
+	assert.Equal(t, "A1", receipt.SeatLines[0].Seat)
+	occupied, err := s.store.OccupiedSeats(testDepartureID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"A1": resp.ReceiptId}, occupied)
+}
+
+func TestCancelTicketCheckedIn(t *testing.T) {
+	s := newTestServer()
+	payments := newFakePaymentProvider()
+	s.payments = payments
+	ctx := context.Background()
+	s.now = func() time.Time { return time.Date(2030, 9, 1, 7, 45, 0, 0, time.UTC) }
+	boarded, err := purchaseWithToken(s, "john.doe@example.com", "A1", "")
+	require.NoError(t, err)
+	other, err := s.PurchaseTicket(ctx, &pb.PurchaseRequest{
+		From:          "London",
+		To:            "Paris",
+		DepartureId:   "ES9024-20300901",
+		User:          &pb.User{Email: "john.doe@example.com"},
+		PreferredSeat: "A1",
+	})
+	require.NoError(t, err)
+	pass, err := s.GetBoardingPass(ctx, &pb.GetBoardingPassRequest{ReceiptId: boarded.ReceiptId})
+	require.NoError(t, err)
+	_, err = s.ValidateTicket(ctx, &pb.ValidateTicketRequest{Token: pass.Token, DepartureId: testDepartureID})
+	require.NoError(t, err)
+
+	// A seat that has been boarded can no longer be cancelled or refunded
+	_, err = s.CancelTicket(ctx, &pb.CancelTicketRequest{ReceiptId: boarded.ReceiptId})
+	_, reason := errorDetails(err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, reasonAlreadyCheckedIn, reason)
+
+	// Removing the passenger cancels none of their tickets, not even the
+	// ones they have not boarded with
+	_, err = s.RemoveUser(ctx, &pb.RemoveUserRequest{Email: "john.doe@example.com"})
+	_, reason = errorDetails(err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, reasonAlreadyCheckedIn, reason)
+
+	for _, receiptID := range []string{boarded.ReceiptId, other.ReceiptId} {
+		receipt, err := s.GetReceipt(ctx, &pb.ReceiptRequest{ReceiptId: receiptID})
+		require.NoError(t, err)
+		assert.Equal(t, pb.BookingStatus_BOOKING_STATUS_CONFIRMED, receipt.Status)
+		assert.Len(t, receipt.SeatLines, 1)
+		assert.Empty(t, receipt.Refunds)
+	}
+	for _, authorization := range payments.authorizations {
+		assert.Zero(t, authorization.refunded)
+	}
+	occupied, err := s.store.OccupiedSeats("ES9024-20300901")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"A1": other.ReceiptId}, occupied)
+}
+
+func TestLoadCancellationPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cancellation.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"full_refund_hours": 24, "partial_refund_percent": 25}`), 0600))
+	p, err := loadCancellationPolicy(path)
+	require.NoError(t, err)
+	assert.Equal(t, &cancellationPolicy{FullRefundHours: 24, PartialRefundPercent: 25}, p)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"partial_refund_percent": 150}`), 0600))
+	_, err = loadCancellationPolicy(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	dateLayout          = "2006-01-02"
+	departureDateLayout = "20060102"
+	timeOfDayLayout     = "15:04"
+)
+
+// catalog is the network of stations, routes and trains that tickets are
+// sold against. Trains run every day, so a departure is identified by the
+// train and the date it runs on, e.g. "ES9010-20240901".
+type catalog struct {
+	Stations []*station `json:"stations"`
+	Routes   []*route   `json:"routes"`
+	Trains   []*train   `json:"trains"`
+
+	stationsByKey map[string]*station
+	routesByID    map[string]*route
+	trainsByID    map[string]*train
+}
+
+type station struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// routeStop is a call at a station, measured from the start of the route.
+type routeStop struct {
+	Station    string `json:"station"`
+	Minutes    int    `json:"minutes"`
+	DistanceKm int    `json:"distance_km"`
+}
+
+type route struct {
+	ID    string       `json:"id"`
+	Stops []*routeStop `json:"stops"`
+}
+
+type train struct {
+	ID       string           `json:"id"`
+	Name     string           `json:"name"`
+	Route    string           `json:"route"`
+	Departs  string           `json:"departs"`  // time of day at the first stop, "15:04" UTC
+	Sections []*sectionLayout `json:"sections"` // defaults to defaultSections()
+}
+
+// departure is a train running on a specific date.
+type departure struct {
+	ID    string
+	Train *train
+	Route *route
+	Date  time.Time
+}
+
+// defaultCatalog returns the built-in Channel Tunnel network.
+func defaultCatalog() *catalog {
+	c := &catalog{
+		Stations: []*station{
+			{ID: "LON", Name: "London"},
+			{ID: "ASH", Name: "Ashford"},
+			{ID: "LIL", Name: "Lille"},
+			{ID: "PAR", Name: "Paris"},
+			{ID: "BRU", Name: "Brussels"},
+		},
+		Routes: []*route{
+			{ID: "LON-PAR", Stops: []*routeStop{
+				{Station: "LON", Minutes: 0, DistanceKm: 0},
+				{Station: "ASH", Minutes: 37, DistanceKm: 90},
+				{Station: "LIL", Minutes: 82, DistanceKm: 270},
+				{Station: "PAR", Minutes: 137, DistanceKm: 492},
+			}},
+			{ID: "PAR-LON", Stops: []*routeStop{
+				{Station: "PAR", Minutes: 0, DistanceKm: 0},
+				{Station: "LIL", Minutes: 62, DistanceKm: 222},
+				{Station: "ASH", Minutes: 107, DistanceKm: 402},
+				{Station: "LON", Minutes: 137, DistanceKm: 492},
+			}},
+			{ID: "LON-BRU", Stops: []*routeStop{
+				{Station: "LON", Minutes: 0, DistanceKm: 0},
+				{Station: "ASH", Minutes: 37, DistanceKm: 90},
+				{Station: "LIL", Minutes: 82, DistanceKm: 270},
+				{Station: "BRU", Minutes: 121, DistanceKm: 373},
+			}},
+			{ID: "BRU-LON", Stops: []*routeStop{
+				{Station: "BRU", Minutes: 0, DistanceKm: 0},
+				{Station: "LIL", Minutes: 39, DistanceKm: 103},
+				{Station: "ASH", Minutes: 84, DistanceKm: 283},
+				{Station: "LON", Minutes: 121, DistanceKm: 373},
+			}},
+		},
+		Trains: []*train{
+			{ID: "ES9002", Name: "Eurostar 9002", Route: "LON-PAR", Departs: "06:01"},
+			{ID: "ES9010", Name: "Eurostar 9010", Route: "LON-PAR", Departs: "08:01"},
+			{ID: "ES9024", Name: "Eurostar 9024", Route: "LON-PAR", Departs: "11:31"},
+			{ID: "ES9007", Name: "Eurostar 9007", Route: "PAR-LON", Departs: "07:13"},
+			{ID: "ES9031", Name: "Eurostar 9031", Route: "PAR-LON", Departs: "13:13"},
+			{ID: "ES9116", Name: "Eurostar 9116", Route: "LON-BRU", Departs: "08:16"},
+			{ID: "ES9117", Name: "Eurostar 9117", Route: "BRU-LON", Departs: "08:52"},
+		},
+	}
+	if err := c.index(); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// loadCatalog reads a catalog from a JSON file.
+func loadCatalog(path string) (*catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &catalog{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("parse catalog %s: %w", path, err)
+	}
+	if err := c.index(); err != nil {
+		return nil, fmt.Errorf("catalog %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// index validates the catalog and builds its lookup tables.
+func (c *catalog) index() error {
+	c.stationsByKey = make(map[string]*station)
+	c.routesByID = make(map[string]*route)
+	c.trainsByID = make(map[string]*train)
+
+	for _, st := range c.Stations {
+		if st.ID == "" || st.Name == "" {
+			return fmt.Errorf("station %q needs an id and a name", st.ID)
+		}
+		c.stationsByKey[strings.ToLower(st.ID)] = st
+		c.stationsByKey[strings.ToLower(st.Name)] = st
+	}
+	for _, r := range c.Routes {
+		if len(r.Stops) < 2 {
+			return fmt.Errorf("route %q needs at least two stops", r.ID)
+		}
+		for _, stop := range r.Stops {
+			if _, ok := c.stationsByKey[strings.ToLower(stop.Station)]; !ok {
+				return fmt.Errorf("route %q calls at unknown station %q", r.ID, stop.Station)
+			}
+		}
+		c.routesByID[r.ID] = r
+	}
+	for _, t := range c.Trains {
+		if strings.Contains(t.ID, "-") {
+			return fmt.Errorf("train id %q must not contain '-'", t.ID)
+		}
+		if _, ok := c.routesByID[t.Route]; !ok {
+			return fmt.Errorf("train %q runs on unknown route %q", t.ID, t.Route)
+		}
+		if _, err := time.Parse(timeOfDayLayout, t.Departs); err != nil {
+			return fmt.Errorf("train %q has invalid departure time %q", t.ID, t.Departs)
+		}
+		if len(t.Sections) == 0 {
+			t.Sections = defaultSections()
+		}
+		if err := validateSections(t.ID, t.Sections); err != nil {
+			return err
+		}
+		c.trainsByID[t.ID] = t
+	}
+	return nil
+}
+
+// station looks a station up by its id or name, ignoring case.
+func (c *catalog) station(key string) (*station, bool) {
+	st, ok := c.stationsByKey[strings.ToLower(strings.TrimSpace(key))]
+	return st, ok
+}
+
+// departures returns the departures on date that call at from and later at to,
+// in order of departure time.
+func (c *catalog) departures(from, to *station, date time.Time) []*departure {
+	var deps []*departure
+	for _, t := range c.Trains {
+		d := c.newDeparture(t, date)
+		if _, _, ok := d.segment(from, to); ok {
+			deps = append(deps, d)
+		}
+	}
+	sort.Slice(deps, func(i, j int) bool {
+		return deps[i].stopTime(deps[i].Route.Stops[0]).Before(deps[j].stopTime(deps[j].Route.Stops[0]))
+	})
+	return deps
+}
+
+// departure looks up a departure by its id.
+func (c *catalog) departure(id string) (*departure, bool) {
+	i := strings.LastIndex(id, "-")
+	if i < 0 {
+		return nil, false
+	}
+	t, ok := c.trainsByID[id[:i]]
+	if !ok {
+		return nil, false
+	}
+	date, err := time.Parse(departureDateLayout, id[i+1:])
+	if err != nil {
+		return nil, false
+	}
+	return c.newDeparture(t, date), true
+}
+
+func (c *catalog) newDeparture(t *train, date time.Time) *departure {
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+	return &departure{
+		ID:    t.ID + "-" + date.Format(departureDateLayout),
+		Train: t,
+		Route: c.routesByID[t.Route],
+		Date:  date,
+	}
+}
+
+// segment returns the stops at which a passenger boards at from and alights at
+// to, or false if the departure does not serve that journey.
+func (d *departure) segment(from, to *station) (*routeStop, *routeStop, bool) {
+	var board *routeStop
+	for _, stop := range d.Route.Stops {
+		switch {
+		case board == nil && strings.EqualFold(stop.Station, from.ID):
+			board = stop
+		case board != nil && strings.EqualFold(stop.Station, to.ID):
+			return board, stop, true
+		}
+	}
+	return nil, nil, false
+}
+
+// stop returns the stop at which the departure calls at st, or false if it
+// does not.
+func (d *departure) stop(st *station) (*routeStop, bool) {
+	for _, stop := range d.Route.Stops {
+		if strings.EqualFold(stop.Station, st.ID) {
+			return stop, true
+		}
+	}
+	return nil, false
+}
+
+// stopTime returns when the train calls at stop.
+func (d *departure) stopTime(stop *routeStop) time.Time {
+	departs, _ := time.Parse(timeOfDayLayout, d.Train.Departs)
+	return d.Date.Add(time.Duration(departs.Hour())*time.Hour +
+		time.Duration(departs.Minute()+stop.Minutes)*time.Minute)
+}
+
+func (st *station) toProto() *pb.Station {
+	return &pb.Station{Id: st.ID, Name: st.Name}
+}
+
+// toProto describes the departure for a passenger travelling from → to.
+func (d *departure) toProto(from, to *station) *pb.Departure {
+	board, alight, _ := d.segment(from, to)
+	return &pb.Departure{
+		Id:            d.ID,
+		TrainId:       d.Train.ID,
+		TrainName:     d.Train.Name,
+		RouteId:       d.Route.ID,
+		From:          from.toProto(),
+		To:            to.toProto(),
+		Date:          d.Date.Format(dateLayout),
+		DepartureTime: timestamppb.New(d.stopTime(board)),
+		ArrivalTime:   timestamppb.New(d.stopTime(alight)),
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCatalogDepartureLookup(t *testing.T) {
+	c := defaultCatalog()
+
+	dep, ok := c.departure("ES9010-20300901")
+	require.True(t, ok)
+	assert.Equal(t, "ES9010", dep.Train.ID)
+	assert.Equal(t, "LON-PAR", dep.Route.ID)
+
+	for _, id := range []string{"ES9010", "ES9999-20300901", "ES9010-2030-09-01", ""} {
+		_, ok := c.departure(id)
+		assert.False(t, ok, id)
+	}
+}
+
+func TestCatalogStationLookup(t *testing.T) {
+	c := defaultCatalog()
+
+	for _, key := range []string{"PAR", "par", "Paris", " paris "} {
+		st, ok := c.station(key)
+		require.True(t, ok, key)
+		assert.Equal(t, "PAR", st.ID)
+	}
+	_, ok := c.station("France")
+	assert.False(t, ok)
+}
+
+func TestListDepartures(t *testing.T) {
+	s := newTestServer()
+
+	resp, err := s.ListDepartures(context.Background(), &pb.ListDeparturesRequest{From: "Ashford", To: "Lille", Date: "2030-09-01"})
+	require.NoError(t, err)
+
+	var ids []string
+	for _, dep := range resp.Departures {
+		ids = append(ids, dep.Id)
+	}
+	// Every London → Paris and London → Brussels train calls at Ashford then Lille.
+	assert.Equal(t, []string{"ES9002-20300901", "ES9010-20300901", "ES9116-20300901", "ES9024-20300901"}, ids)
+
+	dep := resp.Departures[1]
+	assert.Equal(t, "ASH", dep.From.Id)
+	assert.Equal(t, "LIL", dep.To.Id)
+	assert.Equal(t, time.Date(2030, 9, 1, 8, 38, 0, 0, time.UTC), dep.DepartureTime.AsTime())
+	assert.Equal(t, time.Date(2030, 9, 1, 9, 23, 0, 0, time.UTC), dep.ArrivalTime.AsTime())
+
+	// Travelling against the direction of the route is not served.
+	resp, err = s.ListDepartures(context.Background(), &pb.ListDeparturesRequest{From: "Lille", To: "Ashford", Date: "2030-09-01"})
+	require.NoError(t, err)
+	for _, dep := range resp.Departures {
+		assert.Contains(t, []string{"PAR-LON", "BRU-LON"}, dep.RouteId)
+	}
+
+	_, err = s.ListDepartures(context.Background(), &pb.ListDeparturesRequest{From: "London", To: "Paris", Date: "01/09/2030"})
+	assert.Error(t, err)
+	_, err = s.ListDepartures(context.Background(), &pb.ListDeparturesRequest{From: "London", To: "Atlantis", Date: "2030-09-01"})
+	assert.Error(t, err)
+}
+
+func TestPurchaseTicketRejectsUnknownJourney(t *testing.T) {
+	s := newTestServer()
+	user := &pb.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"}
+
+	tests := []struct {
+		name string
+		req  *pb.PurchaseRequest
+	}{
+		{"unknown station", &pb.PurchaseRequest{From: "London", To: "France", DepartureId: testDepartureID, User: user}},
+		{"missing departure", &pb.PurchaseRequest{From: "London", To: "Paris", User: user}},
+		{"unknown departure", &pb.PurchaseRequest{From: "London", To: "Paris", DepartureId: "ES0000-20300901", User: user}},
+		{"departure on another route", &pb.PurchaseRequest{From: "London", To: "Brussels", DepartureId: testDepartureID, User: user}},
+		{"wrong direction", &pb.PurchaseRequest{From: "Paris", To: "London", DepartureId: testDepartureID, User: user}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.PurchaseTicket(context.Background(), tt.req)
+			assert.Error(t, err)
+			assert.Nil(t, resp)
+		})
+	}
+}
+
+func TestLoadCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"stations": [{"id": "EDB", "name": "Edinburgh"}, {"id": "KGX", "name": "London Kings Cross"}],
+		"routes": [{"id": "KGX-EDB", "stops": [
+			{"station": "KGX", "minutes": 0, "distance_km": 0},
+			{"station": "EDB", "minutes": 260, "distance_km": 632}
+		]}],
+		"trains": [{"id": "LNER1", "name": "Flying Scotsman", "route": "KGX-EDB", "departs": "10:00"}]
+	}`), 0600))
+
+	c, err := loadCatalog(path)
+	require.NoError(t, err)
+	from, _ := c.station("London Kings Cross")
+	to, _ := c.station("edb")
+	deps := c.departures(from, to, time.Date(2030, 9, 1, 0, 0, 0, 0, time.UTC))
+	require.Len(t, deps, 1)
+	assert.Equal(t, "LNER1-20300901", deps[0].ID)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"routes": [{"id": "R", "stops": [{"station": "X"}, {"station": "Y"}]}]}`), 0600))
+	_, err = loadCatalog(path)
+	assert.Error(t, err)
+	// Seat A11 would belong to both sections
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"stations": [{"id": "EDB", "name": "Edinburgh"}, {"id": "KGX", "name": "London Kings Cross"}],
+		"routes": [{"id": "KGX-EDB", "stops": [{"station": "KGX"}, {"station": "EDB", "minutes": 260}]}],
+		"trains": [{"id": "LNER1", "route": "KGX-EDB", "departs": "10:00", "sections": [
+			{"name": "SectionA", "prefix": "A", "capacity": 20},
+			{"name": "SectionB", "prefix": "A1", "capacity": 20}
+		]}]
+	}`), 0600))
+	_, err = loadCatalog(path)
+	assert.ErrorContains(t, err, "A1")
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// errorDomain identifies this service in ErrorInfo details.
+const errorDomain = "TicketService"
+
+// Reasons carried in ErrorInfo details, so clients can branch on the failure
+// without parsing messages.
+const (
+	reasonDepartureNotFound     = "DEPARTURE_NOT_FOUND"
+	reasonAlreadyDeparted       = "ALREADY_DEPARTED"
+	reasonReceiptNotFound       = "RECEIPT_NOT_FOUND"
+	reasonUserNotFound          = "USER_NOT_FOUND"
+	reasonAlreadyBooked         = "ALREADY_BOOKED"
+	reasonSeatNotFound          = "SEAT_NOT_FOUND"
+	reasonSeatTaken             = "SEAT_TAKEN"
+	reasonSoldOut               = "SOLD_OUT"
+	reasonNotEnoughSeats        = "NOT_ENOUGH_SEATS"
+	reasonPaymentDeclined       = "PAYMENT_DECLINED"
+	reasonPaymentTimeout        = "PAYMENT_TIMEOUT"
+	reasonPaymentFailed         = "PAYMENT_FAILED"
+	reasonBookingCancelled      = "BOOKING_CANCELLED"
+	reasonHoldNotFound          = "HOLD_NOT_FOUND"
+	reasonHoldInUse             = "HOLD_IN_USE"
+	reasonAlreadyWaitlisted     = "ALREADY_WAITLISTED"
+	reasonSeatsAvailable        = "SEATS_AVAILABLE"
+	reasonWaitlistEntryNotFound = "WAITLIST_ENTRY_NOT_FOUND"
+	reasonInvalidBoardingPass   = "INVALID_BOARDING_PASS"
+	reasonWrongDeparture        = "WRONG_DEPARTURE"
+	reasonBoardingPassOutdated  = "BOARDING_PASS_OUTDATED"
+	reasonAlreadyCheckedIn      = "ALREADY_CHECKED_IN"
+	reasonCredentialsMissing    = "CREDENTIALS_MISSING"
+	reasonCredentialsInvalid    = "CREDENTIALS_INVALID"
+	reasonPermissionDenied      = "PERMISSION_DENIED"
+	reasonNotOwner              = "NOT_OWNER"
+)
+
+// fieldViolation describes what is wrong with one request field.
+func fieldViolation(field, description string) *errdetails.BadRequest_FieldViolation {
+	return &errdetails.BadRequest_FieldViolation{Field: field, Description: description}
+}
+
+// badRequest returns an INVALID_ARGUMENT error with a BadRequest detail
+// listing every violation.
+func badRequest(violations ...*errdetails.BadRequest_FieldViolation) error {
+	var msgs []string
+	for _, v := range violations {
+		msgs = append(msgs, v.Description)
+	}
+	st := status.New(codes.InvalidArgument, strings.Join(msgs, "; "))
+	return withDetails(st, &errdetails.BadRequest{FieldViolations: violations})
+}
+
+// errorInfo returns an error with the given code and an ErrorInfo detail
+// carrying reason and metadata.
+func errorInfo(code codes.Code, reason string, metadata map[string]string, format string, args ...interface{}) error {
+	st := status.New(code, fmt.Sprintf(format, args...))
+	return withDetails(st, &errdetails.ErrorInfo{Reason: reason, Domain: errorDomain, Metadata: metadata})
+}
+
+// storeError reports a storage failure as INTERNAL without leaking its
+// details to the caller.
+func storeError(err error) error {
+	log.Printf("store error: %v", err)
+	return status.Error(codes.Internal, "internal storage error")
+}
+
+// internalError reports an unexpected failure as INTERNAL without leaking its
+// details to the caller.
+func internalError(err error) error {
+	log.Printf("internal error: %v", err)
+	return status.Error(codes.Internal, "internal error")
+}
+
+// paymentError reports a failure of the payment provider at stage:
+// "authorize", "capture" or "refund". ctx is the context the provider was called with.
+func paymentError(ctx context.Context, err error, stage string) error {
+	metadata := map[string]string{"stage": stage}
+	switch {
+	case errors.Is(err, errPaymentDeclined):
+		return errorInfo(codes.FailedPrecondition, reasonPaymentDeclined, metadata, "payment declined")
+	case errors.Is(err, errPaymentTimeout), ctx.Err() != nil:
+		return errorInfo(codes.Unavailable, reasonPaymentTimeout, metadata, "payment provider did not respond in time")
+	}
+	log.Printf("payment %s error: %v", stage, err)
+	return errorInfo(codes.Unavailable, reasonPaymentFailed, metadata, "payment could not be taken")
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
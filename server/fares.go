@@ -0,0 +1,225 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Aravinthvvs/gRPC/money"
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+)
+
+// fareRules prices tickets. A passenger's fare is the base fare of the
+// distance band covering their journey, multiplied by the class of their
+// section, their passenger type and how far ahead the ticket was booked.
+// Fares are set in Currency and converted at ExchangeRates for passengers
+// paying in another currency.
+type fareRules struct {
+	Currency      string             `json:"currency"`       // defaults to GBP
+	ExchangeRates map[string]float64 `json:"exchange_rates"` // currency code → units per unit of Currency
+	DistanceBands []*distanceBand    `json:"distance_bands"`
+	Classes       map[string]float64 `json:"classes"`    // section name → multiplier, 1 if absent
+	Passengers    map[string]float64 `json:"passengers"` // adult, child or senior → multiplier
+	Advance       []*advanceRule     `json:"advance"`
+}
+
+// distanceBand is the base fare of journeys up to UpToKm long. The last band
+// may leave UpToKm at 0 to cover every longer journey.
+type distanceBand struct {
+	UpToKm int     `json:"up_to_km"`
+	Fare   float64 `json:"fare"`
+}
+
+// advanceRule applies to tickets booked at least MinDays before travel.
+type advanceRule struct {
+	MinDays    int     `json:"min_days"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// passengerTypes are the keys of fareRules.Passengers.
+var passengerTypes = []string{"adult", "child", "senior"}
+
+// defaultFareRules returns the built-in fares.
+func defaultFareRules() *fareRules {
+	r := &fareRules{
+		Currency:      "GBP",
+		ExchangeRates: map[string]float64{"EUR": 1.17},
+		DistanceBands: []*distanceBand{
+			{UpToKm: 100, Fare: 15},
+			{UpToKm: 300, Fare: 35},
+			{UpToKm: 400, Fare: 55},
+			{Fare: 70},
+		},
+		Classes:    map[string]float64{"SectionA": 1.5, "SectionB": 1},
+		Passengers: map[string]float64{"adult": 1, "child": 0.5, "senior": 0.7},
+		Advance: []*advanceRule{
+			{MinDays: 0, Multiplier: 1.2},
+			{MinDays: 7, Multiplier: 1},
+			{MinDays: 30, Multiplier: 0.8},
+		},
+	}
+	if err := r.validate(); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// loadFareRules reads fare rules from a JSON file.
+func loadFareRules(path string) (*fareRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &fareRules{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("parse fares %s: %w", path, err)
+	}
+	if err := r.validate(); err != nil {
+		return nil, fmt.Errorf("fares %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// validate checks that the rules price every journey.
+func (r *fareRules) validate() error {
+	if r.Currency == "" {
+		r.Currency = "GBP"
+	}
+	if _, ok := money.Lookup(r.Currency); !ok {
+		return fmt.Errorf("unsupported currency %q", r.Currency)
+	}
+	for code, rate := range r.ExchangeRates {
+		if _, ok := money.Lookup(code); !ok {
+			return fmt.Errorf("unsupported currency %q", code)
+		}
+		if rate <= 0 {
+			return fmt.Errorf("exchange rate for %q must be positive", code)
+		}
+	}
+	if len(r.DistanceBands) == 0 {
+		return fmt.Errorf("at least one distance band is required")
+	}
+	for i, band := range r.DistanceBands {
+		last := i == len(r.DistanceBands)-1
+		if band.Fare <= 0 {
+			return fmt.Errorf("distance band %d needs a positive fare", i)
+		}
+		if band.UpToKm == 0 && !last {
+			return fmt.Errorf("only the last distance band may be unbounded")
+		}
+		if i > 0 && band.UpToKm != 0 && band.UpToKm <= r.DistanceBands[i-1].UpToKm {
+			return fmt.Errorf("distance bands must be in increasing order")
+		}
+	}
+	for section, m := range r.Classes {
+		if m <= 0 {
+			return fmt.Errorf("class %q needs a positive multiplier", section)
+		}
+	}
+	for _, t := range passengerTypes {
+		if m, ok := r.Passengers[t]; !ok || m <= 0 {
+			return fmt.Errorf("passenger type %q needs a positive multiplier", t)
+		}
+	}
+	for i, rule := range r.Advance {
+		if rule.MinDays < 0 || rule.Multiplier <= 0 {
+			return fmt.Errorf("advance rule %d needs non-negative min_days and a positive multiplier", i)
+		}
+		if i > 0 && rule.MinDays <= r.Advance[i-1].MinDays {
+			return fmt.Errorf("advance rules must be in increasing order of min_days")
+		}
+	}
+	return nil
+}
+
+// baseFare returns the fare of the band covering distanceKm.
+func (r *fareRules) baseFare(distanceKm int) float64 {
+	for _, band := range r.DistanceBands {
+		if band.UpToKm == 0 || distanceKm <= band.UpToKm {
+			return band.Fare
+		}
+	}
+	return r.DistanceBands[len(r.DistanceBands)-1].Fare
+}
+
+// advanceMultiplier returns the multiplier of the latest advance rule that
+// daysAhead qualifies for, or 1 if there is none.
+func (r *fareRules) advanceMultiplier(daysAhead int) float64 {
+	m := 1.0
+	for _, rule := range r.Advance {
+		if daysAhead >= rule.MinDays {
+			m = rule.Multiplier
+		}
+	}
+	return m
+}
+
+// classMultiplier returns the multiplier of section.
+func (r *fareRules) classMultiplier(section string) float64 {
+	if m, ok := r.Classes[section]; ok {
+		return m
+	}
+	return 1
+}
+
+// currency returns the currency named by code, or the rules' own currency if
+// code is empty, and its exchange rate. It returns false if fares are not
+// offered in code.
+func (r *fareRules) currency(code string) (money.Currency, float64, bool) {
+	if code == "" || strings.EqualFold(code, r.Currency) {
+		c, _ := money.Lookup(r.Currency)
+		return c, 1, true
+	}
+	c, ok := money.Lookup(code)
+	if !ok {
+		return money.Currency{}, 0, false
+	}
+	rate, ok := r.ExchangeRates[c.Code]
+	return c, rate, ok
+}
+
+// fareLine prices one passenger travelling distanceKm in section on a ticket
+// booked daysAhead days before travel, charged in c at rate.
+func (r *fareRules) fareLine(passenger *pb.User, section string, distanceKm, daysAhead int, c money.Currency, rate float64) *pb.FareLine {
+	passengerType := passenger.GetPassengerType()
+	if passengerType == pb.PassengerType_PASSENGER_TYPE_UNSPECIFIED {
+		passengerType = pb.PassengerType_PASSENGER_TYPE_ADULT
+	}
+	base := r.baseFare(distanceKm)
+	classMultiplier := r.classMultiplier(section)
+	passengerMultiplier := r.Passengers[passengerTypeKey(passengerType)]
+	advanceMultiplier := r.advanceMultiplier(daysAhead)
+	return &pb.FareLine{
+		PassengerEmail:      passenger.GetEmail(),
+		PassengerType:       passengerType,
+		Section:             section,
+		DistanceKm:          int32(distanceKm),
+		DaysAhead:           int32(daysAhead),
+		BaseFare:            c.Round(base * rate),
+		ClassMultiplier:     float32(classMultiplier),
+		PassengerMultiplier: float32(passengerMultiplier),
+		AdvanceMultiplier:   float32(advanceMultiplier),
+		Amount:              c.Round(base * classMultiplier * passengerMultiplier * advanceMultiplier * rate),
+	}
+}
+
+// fare prices every seat line of a booking in c at rate. Each line is rounded
+// to the minor unit on its own, so the total is always the sum of the lines.
+func (r *fareRules) fare(lines []*pb.SeatLine, distanceKm, daysAhead int, c money.Currency, rate float64) *pb.FareBreakdown {
+	fare := &pb.FareBreakdown{ExchangeRate: rate}
+	var amounts []*pb.Money
+	for _, line := range lines {
+		fareLine := r.fareLine(line.Passenger, line.Section, distanceKm, daysAhead, c, rate)
+		fare.Lines = append(fare.Lines, fareLine)
+		amounts = append(amounts, fareLine.Amount)
+	}
+	// Every amount is in c, so the sum cannot fail.
+	fare.Total, _ = c.Sum(amounts...)
+	return fare
+}
+
+// passengerTypeKey returns the fare rules key of t, e.g. "child".
+func passengerTypeKey(t pb.PassengerType) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), "PASSENGER_TYPE_"))
+}
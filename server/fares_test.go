@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Aravinthvvs/gRPC/money"
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestFareLine(t *testing.T) {
+	r := defaultFareRules()
+	gbp, _ := money.Lookup("GBP")
+
+	tests := []struct {
+		name          string
+		passengerType pb.PassengerType
+		section       string
+		distanceKm    int
+		daysAhead     int
+		expected      string
+	}{
+		{"short hop booked a week ahead", pb.PassengerType_PASSENGER_TYPE_ADULT, "SectionB", 90, 10, "£15.00"},
+		{"unspecified travels as adult", pb.PassengerType_PASSENGER_TYPE_UNSPECIFIED, "SectionA", 373, 7, "£82.50"},
+		{"senior on the day", pb.PassengerType_PASSENGER_TYPE_SENIOR, "SectionB", 270, 0, "£29.40"},
+		{"child well in advance", pb.PassengerType_PASSENGER_TYPE_CHILD, "SectionA", 492, 40, "£42.00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			passenger := &pb.User{Email: "john.doe@example.com", PassengerType: tt.passengerType}
+			line := r.fareLine(passenger, tt.section, tt.distanceKm, tt.daysAhead, gbp, 1)
+			assert.Equal(t, tt.expected, money.Format(line.Amount))
+			assert.NotEqual(t, pb.PassengerType_PASSENGER_TYPE_UNSPECIFIED, line.PassengerType)
+		})
+	}
+}
+
+func TestPurchaseTicketFare(t *testing.T) {
+	s := newTestServer()
+	s.now = func() time.Time { return time.Date(2030, 8, 31, 18, 0, 0, 0, time.UTC) }
+	adult := &pb.User{Email: "jane.doe@example.com"}
+	child := &pb.User{Email: "kid.doe@example.com", PassengerType: pb.PassengerType_PASSENGER_TYPE_CHILD}
+
+	resp, err := s.PurchaseTicket(context.Background(), &pb.PurchaseRequest{
+		From:        "London",
+		To:          "Ashford",
+		DepartureId: testDepartureID,
+		User:        adult,
+		Passengers:  []*pb.User{adult, child},
+	})
+	require.NoError(t, err)
+	receipt, err := s.GetReceipt(context.Background(), &pb.ReceiptRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+
+	require.Len(t, receipt.Fare.Lines, 2)
+	for _, line := range receipt.Fare.Lines {
+		assert.Equal(t, int32(90), line.DistanceKm)
+		assert.Equal(t, int32(1), line.DaysAhead)
+		assert.Equal(t, "SectionA", line.Section)
+	}
+	assert.Equal(t, "£27.00", money.Format(receipt.Fare.Lines[0].Amount))
+	assert.Equal(t, "£13.50", money.Format(receipt.Fare.Lines[1].Amount))
+	assert.Equal(t, "£40.50", money.Format(receipt.Fare.Total))
+	assert.Equal(t, "£40.50", money.Format(receipt.PricePaid))
+
+	// Fares charged in euros are converted before rounding: 15 × 0.7 × 1.2 × 1.17 = 14.742.
+	senior := &pb.User{Email: "gran.doe@example.com", PassengerType: pb.PassengerType_PASSENGER_TYPE_SENIOR}
+	resp, err = s.PurchaseTicket(context.Background(), &pb.PurchaseRequest{
+		From:         "London",
+		To:           "Ashford",
+		DepartureId:  testDepartureID,
+		User:         senior,
+		CurrencyCode: "eur",
+	})
+	require.NoError(t, err)
+	receipt, err = s.GetReceipt(context.Background(), &pb.ReceiptRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+	assert.Equal(t, 1.17, receipt.Fare.ExchangeRate)
+	assert.Equal(t, "€17.55", money.Format(receipt.Fare.Lines[0].BaseFare))
+	assert.Equal(t, "SectionB", receipt.Fare.Lines[0].Section)
+	assert.Equal(t, "€14.74", money.Format(receipt.PricePaid))
+
+	_, err = s.PurchaseTicket(context.Background(), &pb.PurchaseRequest{
+		From:         "London",
+		To:           "Paris",
+		DepartureId:  testDepartureID,
+		User:         &pb.User{Email: "dollars@example.com"},
+		CurrencyCode: "USD",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.PurchaseTicket(context.Background(), &pb.PurchaseRequest{
+		From:        "London",
+		To:          "Paris",
+		DepartureId: testDepartureID,
+		User:        &pb.User{Email: "odd@example.com", PassengerType: 42},
+	})
+	assert.Error(t, err)
+}
+
+func TestLoadFareRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fares.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"distance_bands": [{"up_to_km": 200, "fare": 10}, {"fare": 25}],
+		"classes": {"SectionA": 2},
+		"passengers": {"adult": 1, "child": 0, "senior": 1},
+		"advance": [{"min_days": 0, "multiplier": 1}]
+	}`), 0600))
+	_, err := loadFareRules(path)
+	assert.Error(t, err, "child fares must be positive")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"distance_bands": [{"up_to_km": 200, "fare": 10}, {"fare": 25}],
+		"classes": {"SectionA": 2},
+		"passengers": {"adult": 1, "child": 0.25, "senior": 1}
+	}`), 0600))
+	r, err := loadFareRules(path)
+	require.NoError(t, err)
+	gbp, rate, ok := r.currency("")
+	require.True(t, ok)
+	assert.Equal(t, "£50.00", money.Format(r.fareLine(&pb.User{}, "SectionA", 492, 0, gbp, rate).Amount))
+	assert.Equal(t, "£2.50", money.Format(r.fareLine(&pb.User{PassengerType: pb.PassengerType_PASSENGER_TYPE_CHILD}, "SectionB", 90, 0, gbp, rate).Amount))
+	_, _, ok = r.currency("EUR")
+	assert.False(t, ok, "no exchange rate configured")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"currency": "EUR",
+		"distance_bands": [{"fare": 25}],
+		"passengers": {"adult": 1, "child": 1, "senior": 1},
+		"exchange_rates": {"JPY": 160}
+	}`), 0600))
+	_, err = loadFareRules(path)
+	assert.Error(t, err, "unsupported currency")
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// seatHold keeps seats on a departure free for a purchase until it expires.
+// Holds live only in the server's memory: they are short-lived and are not
+// worth restoring after a restart.
+type seatHold struct {
+	id          string
+	departureID string
+	seats       []string
+	expiresAt   time.Time
+	// reservedFor is the email the hold was made for, who alone may buy or
+	// release it: the booker who held the seats, or a waitlisted passenger.
+	reservedFor string
+	// receiptID is set while a purchase using the hold is taking payment.
+	receiptID string
+}
+
+func (h *seatHold) expired(now time.Time) bool {
+	return !now.Before(h.expiresAt)
+}
+
+func (h *seatHold) toProto() *pb.Hold {
+	return &pb.Hold{
+		HoldId:      h.id,
+		DepartureId: h.departureID,
+		Seats:       h.seats,
+		ExpiresAt:   timestamppb.New(h.expiresAt),
+		ReservedFor: h.reservedFor,
+	}
+}
+
+func (s *server) HoldSeats(ctx context.Context, req *pb.HoldSeatsRequest) (*pb.HoldSeatsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if req.DepartureId == "" {
+		violations = append(violations, fieldViolation("departure_id", "departure_id is required"))
+	}
+	if len(req.Seats) == 0 {
+		violations = append(violations, fieldViolation("seats", "at least one seat is required"))
+	}
+	if req.Email == "" {
+		violations = append(violations, fieldViolation("email", "email is required"))
+	}
+	seen := make(map[string]bool)
+	for i, seat := range req.Seats {
+		if seen[seat] {
+			violations = append(violations, fieldViolation(fmt.Sprintf("seats[%d]", i), fmt.Sprintf("seat %s is listed more than once", seat)))
+		}
+		seen[seat] = true
+	}
+	if len(violations) > 0 {
+		return nil, badRequest(violations...)
+	}
+	dep, ok := s.catalog.departure(req.DepartureId)
+	if !ok {
+		return nil, errorInfo(codes.NotFound, reasonDepartureNotFound, map[string]string{"departure_id": req.DepartureId},
+			"departure %q not found", req.DepartureId)
+	}
+
+	// Every seat must exist and be free
+	seats, err := s.seatMap(dep)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	for _, seat := range req.Seats {
+		if _, err := seats.claim(seat, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	hold := s.newHold(dep.ID, append([]string(nil), req.Seats...), req.Email)
+	s.publishSeats(dep.ID)
+	return &pb.HoldSeatsResponse{Hold: hold.toProto()}, nil
+}
+
+func (s *server) ReleaseHold(ctx context.Context, req *pb.ReleaseHoldRequest) (*pb.ReleaseHoldResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if req.HoldId == "" {
+		violations = append(violations, fieldViolation("hold_id", "hold_id is required"))
+	}
+	if req.Email == "" {
+		violations = append(violations, fieldViolation("email", "email is required"))
+	}
+	if len(violations) > 0 {
+		return nil, badRequest(violations...)
+	}
+	hold, err := s.hold(req.HoldId)
+	if err != nil {
+		return nil, err
+	}
+	if err := hold.checkOwner(req.Email); err != nil {
+		return nil, err
+	}
+	s.dropHold(hold, pb.WaitlistStatus_WAITLIST_STATUS_LEFT)
+	if err := s.promoteWaitlist(hold.departureID); err != nil {
+		return nil, err
+	}
+	s.publishSeats(hold.departureID)
+
+	return &pb.ReleaseHoldResponse{Success: true}, nil
+}
+
+// newHold keeps seats on departureID free for holdTTL, for reservedFor alone.
+func (s *server) newHold(departureID string, seats []string, reservedFor string) *seatHold {
+	hold := &seatHold{
+		id:          newHoldID(),
+		departureID: departureID,
+		seats:       seats,
+		expiresAt:   s.now().Add(s.holdTTL),
+		reservedFor: reservedFor,
+	}
+	s.holds[hold.id] = hold
+	return hold
+}
+
+// newHoldID returns a random hold ID. Anyone who knows a hold's ID can try to
+// use it, so IDs must not be guessable.
+func newHoldID() string {
+	return randomID("hold-")
+}
+
+// randomID returns prefix followed by 80 random bits in lowercase base32.
+// Reading crypto/rand does not fail.
+func randomID(prefix string) string {
+	b := make([]byte, 10)
+	rand.Read(b)
+	return prefix + strings.ToLower(base32.StdEncoding.EncodeToString(b))
+}
+
+// checkOwner fails with PERMISSION_DENIED unless the hold was made for email.
+func (h *seatHold) checkOwner(email string) error {
+	if email == h.reservedFor {
+		return nil
+	}
+	return errorInfo(codes.PermissionDenied, reasonNotOwner, map[string]string{"hold_id": h.id},
+		"hold %s was made for another passenger", h.id)
+}
+
+// dropHold deletes hold. If it was made for a waitlisted passenger, their
+// entry leaves the waitlist with status.
+func (s *server) dropHold(hold *seatHold, status pb.WaitlistStatus) {
+	delete(s.holds, hold.id)
+	if entry := s.waitlistEntryFor(hold.id); entry != nil {
+		s.finishWaitlistEntry(entry, status)
+	}
+}
+
+// hold returns the live hold holdID. It fails with NOT_FOUND if the hold does
+// not exist or has expired, and with FAILED_PRECONDITION if a purchase is
+// using it.
+func (s *server) hold(holdID string) (*seatHold, error) {
+	hold, ok := s.holds[holdID]
+	if !ok || (hold.receiptID == "" && hold.expired(s.now())) {
+		return nil, errorInfo(codes.NotFound, reasonHoldNotFound, map[string]string{"hold_id": holdID},
+			"hold %q not found or expired", holdID)
+	}
+	if hold.receiptID != "" {
+		return nil, errorInfo(codes.FailedPrecondition, reasonHoldInUse, map[string]string{"hold_id": holdID, "receipt_id": hold.receiptID},
+			"hold %q is being purchased as %s", holdID, hold.receiptID)
+	}
+	return hold, nil
+}
+
+// holdFor returns the hold being purchased as receiptID, or nil.
+func (s *server) holdFor(receiptID string) *seatHold {
+	for _, hold := range s.holds {
+		if hold.receiptID == receiptID {
+			return hold
+		}
+	}
+	return nil
+}
+
+// expireHolds drops holds that expired by now, except those a purchase is
+// still using, and offers their seats to the waitlist.
+func (s *server) expireHolds(now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expired := 0
+	departures := make(map[string]bool)
+	for _, hold := range s.holds {
+		if hold.receiptID == "" && hold.expired(now) {
+			s.dropHold(hold, pb.WaitlistStatus_WAITLIST_STATUS_EXPIRED)
+			departures[hold.departureID] = true
+			expired++
+		}
+	}
+	for departureID := range departures {
+		if err := s.promoteWaitlist(departureID); err != nil {
+			return 0, err
+		}
+		s.publishSeats(departureID)
+	}
+	return expired, nil
+}
+
+// reapHolds expires stale holds every interval until ctx is done.
+func (s *server) reapHolds(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.expireHolds(s.now())
+			if err != nil {
+				log.Printf("expire seat holds: %v", err)
+			} else if n > 0 {
+				log.Printf("expired %d seat holds", n)
+			}
+		}
+	}
+}
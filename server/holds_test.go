@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHoldSeats(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	now := time.Date(2030, 8, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	holdResp, err := s.HoldSeats(ctx, &pb.HoldSeatsRequest{DepartureId: testDepartureID, Seats: []string{"A1", "A2"}, Email: "jane.doe@example.com"})
+	require.NoError(t, err)
+	hold := holdResp.Hold
+	assert.Regexp(t, "^hold-[a-z2-7]{16}$", hold.HoldId)
+	assert.Equal(t, []string{"A1", "A2"}, hold.Seats)
+	assert.Equal(t, now.Add(s.holdTTL), hold.ExpiresAt.AsTime())
+	assert.Equal(t, "jane.doe@example.com", hold.ReservedFor)
+
+	// Held seats cannot be bought or held by anyone else
+	_, err = purchaseWithToken(s, "john.doe@example.com", "A1", "")
+	_, reason := errorDetails(err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, reasonSeatTaken, reason)
+	_, err = s.HoldSeats(ctx, &pb.HoldSeatsRequest{DepartureId: testDepartureID, Seats: []string{"A3", "A2"}, Email: "john.doe@example.com"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.NotContains(t, []string{"A1", "A2"}, purchaseSeats(t, s, "SectionA")[0])
+
+	// The hold must match the departure and party
+	adult := &pb.User{Email: "jane.doe@example.com"}
+	child := &pb.User{Email: "kid.doe@example.com", PassengerType: pb.PassengerType_PASSENGER_TYPE_CHILD}
+	_, err = s.PurchaseTicket(ctx, &pb.PurchaseRequest{
+		From:        "London",
+		To:          "Paris",
+		DepartureId: testDepartureID,
+		User:        adult,
+		HoldId:      hold.HoldId,
+	})
+	fields, _ := errorDetails(err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, []string{"hold_id"}, fields)
+
+	// Only the booker it was made for can buy it
+	_, err = s.PurchaseTicket(ctx, &pb.PurchaseRequest{
+		From:        "London",
+		To:          "Paris",
+		DepartureId: testDepartureID,
+		User:        &pb.User{Email: "john.doe@example.com"},
+		Passengers:  []*pb.User{{Email: "john.doe@example.com"}, child},
+		HoldId:      hold.HoldId,
+	})
+	_, reason = errorDetails(err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, reasonNotOwner, reason)
+
+	// Buying the hold takes exactly its seats and uses it up
+	resp, err := s.PurchaseTicket(ctx, &pb.PurchaseRequest{
+		From:        "London",
+		To:          "Paris",
+		DepartureId: testDepartureID,
+		User:        adult,
+		Passengers:  []*pb.User{adult, child},
+		HoldId:      hold.HoldId,
+	})
+	require.NoError(t, err)
+	receipt, err := s.GetReceipt(ctx, &pb.ReceiptRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+	require.Len(t, receipt.SeatLines, 2)
+	assert.Equal(t, "A1", receipt.SeatLines[0].Seat)
+	assert.Equal(t, "A2", receipt.SeatLines[1].Seat)
+	assert.Empty(t, s.holds)
+	_, err = s.ReleaseHold(ctx, &pb.ReleaseHoldRequest{HoldId: hold.HoldId, Email: "jane.doe@example.com"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestHoldSeatsExpiry(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	now := time.Date(2030, 8, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	holdResp, err := s.HoldSeats(ctx, &pb.HoldSeatsRequest{DepartureId: testDepartureID, Seats: []string{"A1"}, Email: "jane.doe@example.com"})
+	require.NoError(t, err)
+	expired, err := s.expireHolds(now)
+	require.NoError(t, err)
+	assert.Equal(t, 0, expired)
+
+	// Once the hold lapses the seat is free again and the hold is gone
+	now = now.Add(s.holdTTL)
+	_, err = s.PurchaseTicket(ctx, &pb.PurchaseRequest{
+		From:        "London",
+		To:          "Paris",
+		DepartureId: testDepartureID,
+		User:        &pb.User{Email: "jane.doe@example.com"},
+		HoldId:      holdResp.Hold.HoldId,
+	})
+	_, reason := errorDetails(err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, reasonHoldNotFound, reason)
+	expired, err = s.expireHolds(now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, expired)
+	assert.Empty(t, s.holds)
+	_, err = purchaseWithToken(s, "john.doe@example.com", "A1", "")
+	assert.NoError(t, err)
+}
+
+func TestReleaseHold(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+
+	holdResp, err := s.HoldSeats(ctx, &pb.HoldSeatsRequest{DepartureId: testDepartureID, Seats: []string{"A1"}, Email: "jane.doe@example.com"})
+	require.NoError(t, err)
+
+	// Nobody else can let the seats go
+	_, err = s.ReleaseHold(ctx, &pb.ReleaseHoldRequest{HoldId: holdResp.Hold.HoldId, Email: "john.doe@example.com"})
+	_, reason := errorDetails(err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, reasonNotOwner, reason)
+	_, err = purchaseWithToken(s, "john.doe@example.com", "A1", "")
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	releaseResp, err := s.ReleaseHold(ctx, &pb.ReleaseHoldRequest{HoldId: holdResp.Hold.HoldId, Email: "jane.doe@example.com"})
+	require.NoError(t, err)
+	assert.True(t, releaseResp.Success)
+	_, err = purchaseWithToken(s, "john.doe@example.com", "A1", "")
+	assert.NoError(t, err)
+
+	_, err = s.ReleaseHold(ctx, &pb.ReleaseHoldRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	fields, _ := errorDetails(err)
+	assert.Equal(t, []string{"hold_id", "email"}, fields)
+}
+
+func TestHoldSurvivesFailedPayment(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+
+	holdResp, err := s.HoldSeats(ctx, &pb.HoldSeatsRequest{DepartureId: testDepartureID, Seats: []string{"A1"}, Email: "jane.doe@example.com"})
+	require.NoError(t, err)
+	req := &pb.PurchaseRequest{
+		From:         "London",
+		To:           "Paris",
+		DepartureId:  testDepartureID,
+		User:         &pb.User{Email: "jane.doe@example.com"},
+		HoldId:       holdResp.Hold.HoldId,
+		PaymentToken: fakeTokenDecline,
+	}
+	_, err = s.PurchaseTicket(ctx, req)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// The seat goes back to the hold, which can be bought with another card
+	_, err = purchaseWithToken(s, "john.doe@example.com", "A1", "")
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	req.PaymentToken = ""
+	_, err = s.PurchaseTicket(ctx, req)
+	assert.NoError(t, err)
+}
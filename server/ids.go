@@ -0,0 +1,131 @@
+package main
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/oklog/ulid/v2"
+)
+
+// errMistypedID is returned by IDGenerator.Canonical for IDs whose check
+// character does not match, which are almost certainly typos.
+var errMistypedID = errors.New("check character does not match")
+
+// IDGenerator mints receipt IDs.
+type IDGenerator interface {
+	// NewID returns a new ID. IDs need not be unique on their own: the server
+	// draws again if an ID is already taken.
+	NewID() (string, error)
+	// Canonical returns id, as a person might type it, in the form NewID
+	// produces: for instance upper-cased. It returns errMistypedID if id fails
+	// its check character.
+	Canonical(id string) (string, error)
+}
+
+// newIDGenerator returns the generator registered under name, for receipts
+// kept in the store registered under storeKind.
+func newIDGenerator(name, storeKind string) (IDGenerator, error) {
+	switch name {
+	case "pnr":
+		return pnrIDs{}, nil
+	case "ulid":
+		return ulidIDs{}, nil
+	case "sequential":
+		// The count starts again at rec-1 with every process, when the
+		// receipts of earlier ones are still stored
+		if storeKind != "memory" {
+			return nil, fmt.Errorf("sequential IDs can only be used with the memory store, not %q", storeKind)
+		}
+		return &sequentialIDs{}, nil
+	}
+	return nil, fmt.Errorf("unknown ID generator %q", name)
+}
+
+// crockford is Crockford's base32 alphabet, which leaves out I, L, O and U so
+// codes are easy to read out and type.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// normalizeCrockford upper-cases id, drops hyphens and spaces, and reads the
+// letters Crockford's alphabet leaves out as the digits they resemble.
+func normalizeCrockford(id string) string {
+	return strings.NewReplacer("-", "", " ", "", "I", "1", "L", "1", "O", "0").Replace(strings.ToUpper(id))
+}
+
+// pnrLength is the length of a pnrIDs code, including its check character.
+const pnrLength = 8
+
+// pnrIDs mints short booking codes like airline PNRs: seven random base32
+// characters and a Luhn mod 32 check character, which catches any single
+// mistyped character and most swapped pairs. 32^7 codes keep collisions rare.
+type pnrIDs struct{}
+
+func (pnrIDs) NewID() (string, error) {
+	var b strings.Builder
+	for i := 0; i < pnrLength-1; i++ {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(crockford))))
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(crockford[n.Int64()])
+	}
+	return b.String() + string(luhnMod32(b.String())), nil
+}
+
+func (pnrIDs) Canonical(id string) (string, error) {
+	id = normalizeCrockford(id)
+	if len(id) != pnrLength || strings.Trim(id, crockford) != "" {
+		return id, nil
+	}
+	if luhnMod32(id[:pnrLength-1]) != id[pnrLength-1] {
+		return "", errMistypedID
+	}
+	return id, nil
+}
+
+// luhnMod32 returns the Luhn mod N check character of s, a string over the
+// crockford alphabet.
+func luhnMod32(s string) byte {
+	const n = len(crockford)
+	factor, sum := 2, 0
+	for i := len(s) - 1; i >= 0; i-- {
+		addend := factor * strings.IndexByte(crockford, s[i])
+		sum += addend/n + addend%n
+		factor = 3 - factor
+	}
+	return crockford[(n-sum%n)%n]
+}
+
+// ulidIDs mints ULIDs: 26 characters that sort by creation time and carry 80
+// random bits.
+type ulidIDs struct{}
+
+func (ulidIDs) NewID() (string, error) {
+	return ulid.Make().String(), nil
+}
+
+func (ulidIDs) Canonical(id string) (string, error) {
+	normalized := normalizeCrockford(id)
+	if _, err := ulid.ParseStrict(normalized); err != nil {
+		return id, nil
+	}
+	return normalized, nil
+}
+
+// sequentialIDs mints rec-1, rec-2, … as the server always used to. The IDs
+// are easy to guess, so this is meant for development and tests, and as the
+// count is not stored it only suits the memory store.
+type sequentialIDs struct {
+	n int
+}
+
+func (g *sequentialIDs) NewID() (string, error) {
+	g.n++
+	return fmt.Sprintf("rec-%d", g.n), nil
+}
+
+func (g *sequentialIDs) Canonical(id string) (string, error) {
+	return strings.ToLower(strings.TrimSpace(id)), nil
+}
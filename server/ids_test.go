@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPNRIDs(t *testing.T) {
+	g := pnrIDs{}
+	id, err := g.NewID()
+	require.NoError(t, err)
+	require.Len(t, id, pnrLength)
+	assert.Empty(t, strings.Trim(id, crockford))
+
+	canonical, err := g.Canonical(id)
+	require.NoError(t, err)
+	assert.Equal(t, id, canonical)
+
+	// Case, hyphens and look-alike letters are forgiven
+	code := "7QDM2X0" + string(luhnMod32("7QDM2X0"))
+	canonical, err = g.Canonical(strings.ToLower(strings.Replace(code[:4]+"-"+code[4:], "0", "o", 1)))
+	require.NoError(t, err)
+	assert.Equal(t, code, canonical)
+
+	// Every single-character typo is caught
+	for i := 0; i < pnrLength; i++ {
+		for _, c := range crockford {
+			if byte(c) == code[i] {
+				continue
+			}
+			typo := code[:i] + string(c) + code[i+1:]
+			_, err := g.Canonical(typo)
+			assert.ErrorIs(t, err, errMistypedID, typo)
+		}
+	}
+}
+
+func TestULIDIDs(t *testing.T) {
+	g := ulidIDs{}
+	id, err := g.NewID()
+	require.NoError(t, err)
+	assert.Len(t, id, 26)
+	canonical, err := g.Canonical(strings.ToLower(id))
+	require.NoError(t, err)
+	assert.Equal(t, id, canonical)
+}
+
+// repeatingIDs hands out the same IDs again, as a generator might by chance.
+type repeatingIDs struct {
+	ids []string
+}
+
+func (g *repeatingIDs) NewID() (string, error) {
+	id := g.ids[0]
+	g.ids = g.ids[1:]
+	return id, nil
+}
+
+func (g *repeatingIDs) Canonical(id string) (string, error) { return id, nil }
+
+func TestReceiptIDs(t *testing.T) {
+	s := newServer(newMemoryStore())
+	ctx := context.Background()
+
+	resp, err := purchaseWithToken(s, "john.doe@example.com", "A1", "")
+	require.NoError(t, err)
+	assert.Len(t, resp.ReceiptId, pnrLength)
+
+	// Lookups forgive case, but not a wrong check character
+	receipt, err := s.GetReceipt(ctx, &pb.ReceiptRequest{ReceiptId: strings.ToLower(resp.ReceiptId)})
+	require.NoError(t, err)
+	assert.Equal(t, resp.ReceiptId, receipt.ReceiptId)
+	last := strings.IndexByte(crockford, resp.ReceiptId[pnrLength-1])
+	typo := resp.ReceiptId[:pnrLength-1] + string(crockford[(last+1)%len(crockford)])
+	_, err = s.GetReceipt(ctx, &pb.ReceiptRequest{ReceiptId: typo})
+	fields, _ := errorDetails(err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, []string{"receipt_id"}, fields)
+
+	// A repeated ID is drawn again rather than overwriting a booking
+	s.ids = &repeatingIDs{ids: []string{resp.ReceiptId, "SECOND"}}
+	second, err := purchaseWithToken(s, "jane.doe@example.com", "A2", "")
+	require.NoError(t, err)
+	assert.Equal(t, "SECOND", second.ReceiptId)
+	receipt, err = s.GetReceipt(ctx, &pb.ReceiptRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+	assert.Equal(t, "john.doe@example.com", receipt.User.Email)
+}
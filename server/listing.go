@@ -0,0 +1,312 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// defaultPageSize is used when a list request leaves page_size unset.
+	defaultPageSize = 50
+	// maxPageSize caps page_size; larger requests are served this many.
+	maxPageSize = 1000
+)
+
+// listRequest is implemented by the requests of list RPCs.
+type listRequest interface {
+	proto.Message
+	GetPageSize() int32
+	GetPageToken() string
+	GetFilter() string
+}
+
+// listField is a field that filter expressions can test.
+type listField[T any] struct {
+	// values returns the field's values for an item. A term matches if any of
+	// them does, so tickets match on any of their passengers.
+	values func(T) []string
+	// less orders values for <, <=, > and >=. It defaults to comparing
+	// strings.
+	less func(a, b string) bool
+}
+
+// listPage filters items by the request's filter, puts them in order of key
+// and returns the page the request asks for, the token of the next page and
+// the number of items that matched the filter. Keys must be unique, so the
+// order is stable across requests and pages neither skip nor repeat items
+// when others are added or removed between them.
+func listPage[T any](req listRequest, items []T, fields map[string]listField[T], key func(T) []string) ([]T, string, int32, error) {
+	if req.GetPageSize() < 0 {
+		return nil, "", 0, badRequest(fieldViolation("page_size", "page_size must not be negative"))
+	}
+	size := int(req.GetPageSize())
+	if size == 0 {
+		size = defaultPageSize
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+	terms, err := parseFilter(req.GetFilter(), fields)
+	if err != nil {
+		return nil, "", 0, badRequest(fieldViolation("filter", err.Error()))
+	}
+	query := listQuery(req)
+	var after []string
+	if req.GetPageToken() != "" {
+		token, err := decodePageToken(req.GetPageToken())
+		if err != nil || token.Query != query {
+			return nil, "", 0, badRequest(fieldViolation("page_token", "invalid page token, or the request changed since it was issued"))
+		}
+		after = token.After
+	}
+
+	type keyed struct {
+		item T
+		key  []string
+	}
+	var matched []keyed
+	for _, item := range items {
+		if matchFilter(terms, fields, item) {
+			matched = append(matched, keyed{item, key(item)})
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return compareKeys(matched[i].key, matched[j].key) < 0 })
+
+	start := 0
+	if after != nil {
+		start = sort.Search(len(matched), func(i int) bool { return compareKeys(matched[i].key, after) > 0 })
+	}
+	end := start + size
+	if end > len(matched) {
+		end = len(matched)
+	}
+	var page []T
+	for _, m := range matched[start:end] {
+		page = append(page, m.item)
+	}
+	var next string
+	if end < len(matched) {
+		next = encodePageToken(pageToken{Query: query, After: matched[end-1].key})
+	}
+	return page, next, int32(len(matched)), nil
+}
+
+// compareKeys orders sort keys element by element.
+func compareKeys(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if c := strings.Compare(a[i], b[i]); c != 0 {
+			return c
+		}
+	}
+	return len(a) - len(b)
+}
+
+// seatKey returns a sort key for a seat that orders like seatLess.
+func seatKey(seat string) string {
+	prefix, n := splitSeat(seat)
+	return fmt.Sprintf("%s%09d", prefix, n)
+}
+
+// pageToken is the opaque page_token handed to clients. It remembers the key
+// of the last item served, and which request it belongs to.
+type pageToken struct {
+	Query string   `json:"q"`
+	After []string `json:"a"`
+}
+
+func encodePageToken(t pageToken) string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(s string) (pageToken, error) {
+	var t pageToken
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return t, err
+	}
+	if err := json.Unmarshal(data, &t); err != nil {
+		return t, err
+	}
+	if len(t.After) == 0 {
+		return t, errors.New("page token has no position")
+	}
+	return t, nil
+}
+
+// listQuery fingerprints a list request without its paging fields, so a page
+// token cannot be used with a different filter or order.
+func listQuery(req listRequest) string {
+	m := proto.Clone(req).ProtoReflect()
+	for _, name := range []protoreflect.Name{"page_size", "page_token"} {
+		if fd := m.Descriptor().Fields().ByName(name); fd != nil {
+			m.Clear(fd)
+		}
+	}
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(m.Interface())
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:9])
+}
+
+// filterTerm is one comparison in a filter expression.
+type filterTerm struct {
+	field, op, value string
+}
+
+// filterOps are the comparisons a term can make.
+var filterOps = []string{"!=", "<=", ">=", "=", "<", ">", ":"}
+
+// parseFilter parses a filter expression: terms such as `seat >= A5` or
+// `domain = "example.com"`, joined by AND or just spaces. It is the subset of
+// AIP-160 that dashboards need; OR, NOT and parentheses are not supported.
+func parseFilter[T any](expr string, fields map[string]listField[T]) ([]filterTerm, error) {
+	tokens, err := filterTokens(expr)
+	if err != nil {
+		return nil, err
+	}
+	var terms []filterTerm
+	for len(tokens) > 0 {
+		if tokens[0] == "AND" {
+			if len(terms) == 0 || len(tokens) == 1 {
+				return nil, errors.New("AND must join two terms")
+			}
+			tokens = tokens[1:]
+		}
+		switch tokens[0] {
+		case "OR", "NOT", "(", ")":
+			return nil, fmt.Errorf("%s is not supported, only AND", tokens[0])
+		}
+		if len(tokens) < 3 {
+			return nil, fmt.Errorf("incomplete term %q, expected field, operator and value", strings.Join(tokens, " "))
+		}
+		term := filterTerm{field: tokens[0], op: tokens[1], value: tokens[2]}
+		if _, ok := fields[term.field]; !ok {
+			return nil, fmt.Errorf("unknown field %q, expected one of %s", term.field, strings.Join(fieldNames(fields), ", "))
+		}
+		if !isFilterOp(term.op) {
+			return nil, fmt.Errorf("expected an operator after %s, got %q", term.field, term.op)
+		}
+		terms = append(terms, term)
+		tokens = tokens[3:]
+	}
+	return terms, nil
+}
+
+// filterTokens splits a filter expression into fields, operators and values.
+// Quoted values keep their spaces and lose their quotes.
+func filterTokens(expr string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '"':
+			end := strings.IndexByte(expr[i+1:], '"')
+			if end < 0 {
+				return nil, errors.New("unterminated quoted value")
+			}
+			tokens = append(tokens, expr[i+1:i+1+end])
+			i += end + 2
+		case c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		case strings.ContainsRune("!<>=:", rune(c)):
+			op := expr[i : i+1]
+			if i+1 < len(expr) && expr[i+1] == '=' {
+				op = expr[i : i+2]
+			}
+			tokens = append(tokens, op)
+			i += len(op)
+		default:
+			end := strings.IndexFunc(expr[i:], func(r rune) bool {
+				return unicode.IsSpace(r) || strings.ContainsRune("!<>=:()\"", r)
+			})
+			if end < 0 {
+				end = len(expr) - i
+			}
+			tokens = append(tokens, expr[i:i+end])
+			i += end
+		}
+	}
+	return tokens, nil
+}
+
+func isFilterOp(op string) bool {
+	for _, o := range filterOps {
+		if op == o {
+			return true
+		}
+	}
+	return false
+}
+
+func fieldNames[T any](fields map[string]listField[T]) []string {
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// matchFilter reports whether item satisfies every term.
+func matchFilter[T any](terms []filterTerm, fields map[string]listField[T], item T) bool {
+	for _, term := range terms {
+		field := fields[term.field]
+		// != holds if no value is equal, so a party with one Gmail passenger
+		// does not match domain != gmail.com.
+		negate := term.op == "!="
+		if negate {
+			term.op = "="
+		}
+		matched := false
+		for _, v := range field.values(item) {
+			if matchTerm(term, field.less, v) {
+				matched = true
+				break
+			}
+		}
+		if matched == negate {
+			return false
+		}
+	}
+	return true
+}
+
+// matchTerm tests one value against a term. Equality and contains ignore
+// case.
+func matchTerm(term filterTerm, less func(a, b string) bool, v string) bool {
+	if less == nil {
+		less = func(a, b string) bool { return a < b }
+	}
+	switch term.op {
+	case "=":
+		return strings.EqualFold(v, term.value)
+	case ":":
+		return strings.Contains(strings.ToLower(v), strings.ToLower(term.value))
+	case "<":
+		return less(v, term.value)
+	case "<=":
+		return !less(term.value, v)
+	case ">":
+		return less(term.value, v)
+	case ">=":
+		return !less(v, term.value)
+	}
+	return false
+}
+
+// emailDomain returns the part of an email address after the @.
+func emailDomain(email string) string {
+	return email[strings.LastIndexByte(email, '@')+1:]
+}
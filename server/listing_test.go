@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseFilter(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected []filterTerm
+		err      bool
+	}{
+		{"", nil, false},
+		{"seat>=A5", []filterTerm{{"seat", ">=", "A5"}}, false},
+		{`domain = example.com AND name : "Doe Jr"`, []filterTerm{{"domain", "=", "example.com"}, {"name", ":", "Doe Jr"}}, false},
+		{"seat >= A5 seat <= A12", []filterTerm{{"seat", ">=", "A5"}, {"seat", "<=", "A12"}}, false},
+		{"age > 30", nil, true},
+		{"seat A5", nil, true},
+		{"seat >=", nil, true},
+		{"seat = A1 OR seat = A2", nil, true},
+		{"AND seat = A1", nil, true},
+		{`name = "Doe`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			terms, err := parseFilter(tt.expr, manifestFields)
+			if tt.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, terms)
+		})
+	}
+}
+
+func TestViewUsersPaging(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	for i := 1; i <= 12; i++ {
+		domain := "example.com"
+		if i%3 == 0 {
+			domain = "example.org"
+		}
+		_, err := purchaseWithToken(s, fmt.Sprintf("p%d@%s", i, domain), fmt.Sprintf("A%d", i), "")
+		require.NoError(t, err)
+	}
+	page := func(req *pb.ViewUsersRequest) ([]string, *pb.ViewUsersResponse) {
+		resp, err := s.ViewUsersBySection(ctx, req)
+		require.NoError(t, err)
+		var seats []string
+		for _, us := range resp.UserSeats {
+			seats = append(seats, us.Seat)
+		}
+		return seats, resp
+	}
+
+	req := &pb.ViewUsersRequest{Section: "SectionA", PageSize: 5}
+	seats, resp := page(req)
+	assert.Equal(t, []string{"A1", "A2", "A3", "A4", "A5"}, seats)
+	assert.Equal(t, int32(12), resp.TotalSize)
+
+	// Seats freed or taken before the cursor do not shift the next page
+	_, err := s.RemoveUser(ctx, &pb.RemoveUserRequest{Email: "p2@example.com"})
+	require.NoError(t, err)
+	_, err = purchaseWithToken(s, "late@example.com", "A2", "")
+	require.NoError(t, err)
+	req.PageToken = resp.NextPageToken
+	seats, resp = page(req)
+	assert.Equal(t, []string{"A6", "A7", "A8", "A9", "A10"}, seats)
+	req.PageToken = resp.NextPageToken
+	seats, resp = page(req)
+	assert.Equal(t, []string{"A11", "A12"}, seats)
+	assert.Empty(t, resp.NextPageToken)
+
+	// Filters combine, and seat ranges follow seat order
+	seats, resp = page(&pb.ViewUsersRequest{Section: "SectionA", Filter: "domain = example.com AND seat >= A5 AND seat <= A10"})
+	assert.Equal(t, []string{"A5", "A7", "A8", "A10"}, seats)
+	assert.Equal(t, int32(4), resp.TotalSize)
+	seats, _ = page(&pb.ViewUsersRequest{Section: "SectionA", Filter: "domain != example.com route = LON-PAR"})
+	assert.Equal(t, []string{"A3", "A6", "A9", "A12"}, seats)
+
+	// A token only carries on the request it came from
+	_, resp = page(&pb.ViewUsersRequest{Section: "SectionA", PageSize: 2})
+	_, err = s.ViewUsersBySection(ctx, &pb.ViewUsersRequest{Section: "SectionA", PageSize: 2, PageToken: resp.NextPageToken, Filter: "seat > A1"})
+	fields, _ := errorDetails(err)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, []string{"page_token"}, fields)
+	_, err = s.ViewUsersBySection(ctx, &pb.ViewUsersRequest{Section: "SectionA", PageToken: "garbage"})
+	fields, _ = errorDetails(err)
+	assert.Equal(t, []string{"page_token"}, fields)
+	_, err = s.ViewUsersBySection(ctx, &pb.ViewUsersRequest{Section: "SectionA", PageSize: -1})
+	fields, _ = errorDetails(err)
+	assert.Equal(t, []string{"page_size"}, fields)
+	_, err = s.ViewUsersBySection(ctx, &pb.ViewUsersRequest{Section: "SectionA", Filter: "age > 30"})
+	fields, _ = errorDetails(err)
+	assert.Equal(t, []string{"filter"}, fields)
+}
+
+func TestListPaging(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+
+	stations, err := s.ListStations(ctx, &pb.ListStationsRequest{PageSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, "Ashford", stations.Stations[0].Name)
+	assert.Equal(t, int32(len(s.catalog.Stations)), stations.TotalSize)
+	var names []string
+	for req := (&pb.ListStationsRequest{PageSize: 2}); ; {
+		resp, err := s.ListStations(ctx, req)
+		require.NoError(t, err)
+		for _, st := range resp.Stations {
+			names = append(names, st.Name)
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	assert.Len(t, names, len(s.catalog.Stations))
+	assert.IsIncreasing(t, names)
+
+	departures, err := s.ListDepartures(ctx, &pb.ListDeparturesRequest{From: "London", To: "Paris", Date: "2030-09-01", Filter: "train = ES9024"})
+	require.NoError(t, err)
+	require.Len(t, departures.Departures, 1)
+	assert.Equal(t, "ES9024-20300901", departures.Departures[0].Id)
+
+	first, err := purchaseWithToken(s, "john.doe@example.com", "A1", "")
+	require.NoError(t, err)
+	_, err = s.PurchaseTicket(ctx, &pb.PurchaseRequest{From: "London", To: "Paris", DepartureId: "ES9024-20300901", User: &pb.User{Email: "john.doe@example.com"}, PreferredSeat: "B4"})
+	require.NoError(t, err)
+	_, err = s.CancelTicket(ctx, &pb.CancelTicketRequest{ReceiptId: first.ReceiptId})
+	require.NoError(t, err)
+	tickets, err := s.ListMyTickets(ctx, &pb.ListMyTicketsRequest{Email: "john.doe@example.com", Filter: "status = confirmed"})
+	require.NoError(t, err)
+	require.Len(t, tickets.Receipts, 1)
+	assert.Equal(t, "B4", tickets.Receipts[0].Seat)
+	tickets, err = s.ListMyTickets(ctx, &pb.ListMyTicketsRequest{Email: "john.doe@example.com", Filter: "section = SectionA"})
+	require.NoError(t, err)
+	require.Len(t, tickets.Receipts, 1)
+	assert.Equal(t, first.ReceiptId, tickets.Receipts[0].ReceiptId)
+}
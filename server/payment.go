@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Aravinthvvs/gRPC/money"
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+)
+
+var (
+	// errPaymentDeclined is returned by a PaymentProvider that refuses a payment.
+	errPaymentDeclined = errors.New("payment declined")
+	// errPaymentTimeout is returned by a PaymentProvider whose gateway did not
+	// answer in time.
+	errPaymentTimeout = errors.New("payment gateway timed out")
+)
+
+// paymentRequest asks a PaymentProvider to authorise amount for a booking.
+type paymentRequest struct {
+	ReceiptID string
+	Amount    *pb.Money
+	Token     string // identifies the card or wallet, as issued by the provider
+}
+
+// PaymentProvider takes payment for tickets in two steps: an authorisation
+// holds the money while seats are reserved, and a capture collects it once the
+// booking is confirmed.
+type PaymentProvider interface {
+	// Authorize holds req.Amount and returns an authorisation ID. It returns
+	// errPaymentDeclined if the payment is refused.
+	Authorize(ctx context.Context, req *paymentRequest) (string, error)
+	// Capture collects a held payment.
+	Capture(ctx context.Context, authorizationID string) error
+	// Void releases a held payment that will not be captured.
+	Void(ctx context.Context, authorizationID string) error
+	// Refund pays amount of a captured payment back and returns a refund ID.
+	Refund(ctx context.Context, paymentID string, amount *pb.Money) (string, error)
+}
+
+// newPaymentProvider returns the provider registered under name.
+func newPaymentProvider(name string) (PaymentProvider, error) {
+	switch name {
+	case "fake":
+		return newFakePaymentProvider(), nil
+	}
+	return nil, fmt.Errorf("unknown payment provider %q", name)
+}
+
+// Payment tokens understood by fakePaymentProvider. Any other token, including
+// none, is approved.
+const (
+	fakeTokenDecline        = "tok_decline"
+	fakeTokenTimeout        = "tok_timeout"
+	fakeTokenDeclineCapture = "tok_decline_capture"
+)
+
+// Authorisation states kept by fakePaymentProvider.
+const (
+	paymentAuthorized = "authorized"
+	paymentCaptured   = "captured"
+	paymentVoided     = "voided"
+)
+
+// fakePaymentProvider is an in-process gateway for development and tests. The
+// payment token picks the outcome: tok_decline is refused at authorisation,
+// tok_decline_capture at capture, and tok_timeout never answers.
+type fakePaymentProvider struct {
+	mu             sync.Mutex
+	authorizations map[string]*fakeAuthorization
+}
+
+type fakeAuthorization struct {
+	req      *paymentRequest
+	state    string
+	refunded int64 // minor units paid back
+	refunds  int
+}
+
+func newFakePaymentProvider() *fakePaymentProvider {
+	return &fakePaymentProvider{authorizations: make(map[string]*fakeAuthorization)}
+}
+
+func (p *fakePaymentProvider) Authorize(ctx context.Context, req *paymentRequest) (string, error) {
+	switch req.Token {
+	case fakeTokenDecline:
+		return "", errPaymentDeclined
+	case fakeTokenTimeout:
+		<-ctx.Done()
+		return "", errPaymentTimeout
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	id := fmt.Sprintf("auth-%d", len(p.authorizations)+1)
+	p.authorizations[id] = &fakeAuthorization{req: req, state: paymentAuthorized}
+	return id, nil
+}
+
+func (p *fakePaymentProvider) Capture(ctx context.Context, authorizationID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	auth, ok := p.authorizations[authorizationID]
+	if !ok || auth.state != paymentAuthorized {
+		return fmt.Errorf("authorisation %q cannot be captured", authorizationID)
+	}
+	if auth.req.Token == fakeTokenDeclineCapture {
+		return errPaymentDeclined
+	}
+	auth.state = paymentCaptured
+	return nil
+}
+
+func (p *fakePaymentProvider) Void(ctx context.Context, authorizationID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	auth, ok := p.authorizations[authorizationID]
+	if !ok || auth.state != paymentAuthorized {
+		return fmt.Errorf("authorisation %q cannot be voided", authorizationID)
+	}
+	auth.state = paymentVoided
+	return nil
+}
+
+func (p *fakePaymentProvider) Refund(ctx context.Context, paymentID string, amount *pb.Money) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	auth, ok := p.authorizations[paymentID]
+	if !ok || auth.state != paymentCaptured {
+		return "", fmt.Errorf("payment %q cannot be refunded", paymentID)
+	}
+	if amount.GetCurrencyCode() != auth.req.Amount.GetCurrencyCode() {
+		return "", fmt.Errorf("payment %q was made in %s", paymentID, auth.req.Amount.GetCurrencyCode())
+	}
+	paid, err := money.Minor(auth.req.Amount)
+	if err != nil {
+		return "", err
+	}
+	refund, err := money.Minor(amount)
+	if err != nil {
+		return "", err
+	}
+	if auth.refunded+refund > paid {
+		return "", fmt.Errorf("refunds on payment %q would exceed the amount paid", paymentID)
+	}
+	auth.refunded += refund
+	auth.refunds++
+	return fmt.Sprintf("%s-refund-%d", paymentID, auth.refunds), nil
+}
+
+// state returns the state of an authorisation, or "" if there is none.
+func (p *fakePaymentProvider) state(authorizationID string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if auth, ok := p.authorizations[authorizationID]; ok {
+		return auth.state
+	}
+	return ""
+}
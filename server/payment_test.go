@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func purchaseWithToken(s *server, email, seat, token string) (*pb.PurchaseResponse, error) {
+	return s.PurchaseTicket(context.Background(), &pb.PurchaseRequest{
+		From:          "London",
+		To:            "Paris",
+		DepartureId:   testDepartureID,
+		User:          &pb.User{Email: email},
+		PreferredSeat: seat,
+		PaymentToken:  token,
+	})
+}
+
+func TestPurchaseTicketPayment(t *testing.T) {
+	s := newTestServer()
+	payments := newFakePaymentProvider()
+	s.payments = payments
+	s.paymentTimeout = 20 * time.Millisecond
+	dep, _ := s.catalog.departure(testDepartureID)
+
+	tests := []struct {
+		name           string
+		token          string
+		expectedCode   codes.Code
+		expectedReason string
+		expectedState  string
+	}{
+		{"declined", fakeTokenDecline, codes.FailedPrecondition, reasonPaymentDeclined, ""},
+		{"declined at capture", fakeTokenDeclineCapture, codes.FailedPrecondition, reasonPaymentDeclined, paymentVoided},
+		{"gateway timeout", fakeTokenTimeout, codes.Unavailable, reasonPaymentTimeout, ""},
+		{"approved", "tok_visa", codes.OK, "", paymentCaptured},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(payments.authorizations)
+			resp, err := purchaseWithToken(s, "john.doe@example.com", "A1", tt.token)
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			_, reason := errorDetails(err)
+			assert.Equal(t, tt.expectedReason, reason)
+			if tt.expectedState != "" {
+				assert.Equal(t, tt.expectedState, payments.state(fmt.Sprintf("auth-%d", before+1)))
+			}
+
+			seats, err := s.seatMap(dep)
+			require.NoError(t, err)
+			if tt.expectedCode != codes.OK {
+				// Failed payments release the seat and issue no receipt.
+				assert.Empty(t, seats.occupied)
+				receiptIDs, err := s.store.ReceiptIDsForEmail("john.doe@example.com")
+				require.NoError(t, err)
+				assert.Empty(t, receiptIDs)
+				return
+			}
+			receipt, err := s.GetReceipt(context.Background(), &pb.ReceiptRequest{ReceiptId: resp.ReceiptId})
+			require.NoError(t, err)
+			assert.Equal(t, fmt.Sprintf("auth-%d", before+1), receipt.PaymentId)
+			assert.Equal(t, resp.ReceiptId, seats.occupied["A1"])
+		})
+	}
+	assert.Empty(t, s.pending)
+}
+
+// blockingPaymentProvider holds authorisations paid with tok_slow until
+// release is closed.
+type blockingPaymentProvider struct {
+	*fakePaymentProvider
+	started chan struct{}
+	release chan struct{}
+}
+
+func (p *blockingPaymentProvider) Authorize(ctx context.Context, req *paymentRequest) (string, error) {
+	if req.Token == "tok_slow" {
+		p.started <- struct{}{}
+		<-p.release
+	}
+	return p.fakePaymentProvider.Authorize(ctx, req)
+}
+
+func TestPurchaseTicketHoldsSeatDuringPayment(t *testing.T) {
+	s := newTestServer()
+	payments := &blockingPaymentProvider{
+		fakePaymentProvider: newFakePaymentProvider(),
+		started:             make(chan struct{}, 1),
+		release:             make(chan struct{}),
+	}
+	s.payments = payments
+
+	done := make(chan error)
+	go func() {
+		_, err := purchaseWithToken(s, "john.doe@example.com", "A1", "tok_slow")
+		done <- err
+	}()
+	<-payments.started
+
+	// The reserved seat and passenger are taken, but other bookings go ahead.
+	_, err := purchaseWithToken(s, "jane.doe@example.com", "A1", "")
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = purchaseWithToken(s, "john.doe@example.com", "B1", "")
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	resp, err := purchaseWithToken(s, "jane.doe@example.com", "A2", "")
+	require.NoError(t, err)
+	assert.Equal(t, "rec-2", resp.ReceiptId)
+
+	// Only paid seats reach the store, so a crash now would free A1
+	occupied, err := s.store.OccupiedSeats(testDepartureID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"A2": "rec-2"}, occupied)
+
+	close(payments.release)
+	require.NoError(t, <-done)
+	occupied, err = s.store.OccupiedSeats(testDepartureID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"A1": "rec-1", "A2": "rec-2"}, occupied)
+	receipt, err := s.GetReceipt(context.Background(), &pb.ReceiptRequest{ReceiptId: "rec-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "A1", receipt.Seat)
+}
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Roles of the built-in access policy. Policy files may use any others.
+const (
+	rolePassenger = "passenger"
+	roleAgent     = "agent"
+	roleAdmin     = "admin"
+)
+
+// accessRule says who may call a method. Callers with one of Roles may call
+// it for anyone; callers with one of OwnerRoles only for themselves, that is
+// on tickets they book or travel on, waitlist entries they made, and bookings
+// in their own name. Only the booker may change, cancel or render a ticket;
+// a passenger travelling on someone else's booking only sees their own seat.
+type accessRule struct {
+	Roles      []string `json:"roles"`
+	OwnerRoles []string `json:"owner_roles"`
+}
+
+// accessPolicy holds the rule of each TicketService method, by method name.
+// Methods without a rule of their own follow Default; a policy without a
+// default denies them to everyone.
+type accessPolicy struct {
+	Default accessRule            `json:"default"`
+	Methods map[string]accessRule `json:"methods"`
+}
+
+// defaultAccessPolicy returns the built-in policy: passengers see and change
+// only their own tickets, station agents also see manifests, check tickets
+// and book for others, and only admins remove users.
+func defaultAccessPolicy() *accessPolicy {
+	everyone := []string{rolePassenger, roleAgent, roleAdmin}
+	staff := []string{roleAgent, roleAdmin}
+	ownTickets := accessRule{Roles: staff, OwnerRoles: []string{rolePassenger}}
+	return &accessPolicy{
+		Default: accessRule{Roles: everyone},
+		Methods: map[string]accessRule{
+			"PurchaseTicket":       ownTickets,
+			"GetReceipt":           ownTickets,
+			"ModifySeat":           ownTickets,
+			"CancelTicket":         ownTickets,
+			"ListMyTickets":        ownTickets,
+			"GetBoardingPass":      ownTickets,
+			"RenderReceipt":        ownTickets,
+			"JoinWaitlist":         ownTickets,
+			"LeaveWaitlist":        ownTickets,
+			"WatchWaitlist":        ownTickets,
+			"SeatSelectionSession": ownTickets,
+			"HoldSeats":            ownTickets,
+			"ReleaseHold":          ownTickets,
+			"ViewUsersBySection":   {Roles: staff},
+			"ValidateTicket":       {Roles: staff},
+			"BulkPurchase":         {Roles: staff},
+			"RemoveUser":           {Roles: []string{roleAdmin}},
+		},
+	}
+}
+
+// bookerMethods act on a booking as a whole, so the passengers travelling on
+// it may not call them unless they booked it.
+var bookerMethods = map[string]bool{
+	"CancelTicket":  true,
+	"ModifySeat":    true,
+	"RenderReceipt": true,
+}
+
+// loadAccessPolicy reads an access policy from a JSON file. It replaces the
+// built-in policy as a whole.
+func loadAccessPolicy(path string) (*accessPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := &accessPolicy{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, fmt.Errorf("parse access policy %s: %w", path, err)
+	}
+	methods := make(map[string]bool)
+	for _, m := range pb.TicketService_ServiceDesc.Methods {
+		methods[m.MethodName] = true
+	}
+	for _, m := range pb.TicketService_ServiceDesc.Streams {
+		methods[m.StreamName] = true
+	}
+	for name := range p.Methods {
+		if !methods[name] {
+			return nil, fmt.Errorf("access policy %s: unknown method %q", path, name)
+		}
+	}
+	return p, nil
+}
+
+// rule returns the rule of method.
+func (p *accessPolicy) rule(method string) accessRule {
+	if rule, ok := p.Methods[method]; ok {
+		return rule
+	}
+	return p.Default
+}
+
+// authorizeUnary rejects calls the access policy does not allow. It runs
+// after the auth interceptors, which attach the caller.
+func (s *server) authorizeUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ownerOnly, err := s.authorizeCaller(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	if !ownerOnly {
+		return handler(ctx, req)
+	}
+	method := path.Base(info.FullMethod)
+	if err := s.authorizeOwner(ctx, method, req); err != nil {
+		return nil, err
+	}
+	resp, err := handler(ctx, req)
+	if err != nil {
+		return resp, err
+	}
+	// Passengers on someone else's booking see only their own seats
+	email := callerIdentity(ctx).email
+	switch resp := resp.(type) {
+	case *pb.ReceiptResponse:
+		own, _ := passengerReceipt(resp, email)
+		return own, nil
+	case *pb.ListMyTicketsResponse:
+		var receipts []*pb.ReceiptResponse
+		for _, receipt := range resp.Receipts {
+			if own, ok := passengerReceipt(receipt, email); ok {
+				receipts = append(receipts, own)
+			}
+		}
+		resp.TotalSize -= int32(len(resp.Receipts) - len(receipts))
+		resp.Receipts = receipts
+	}
+	return resp, nil
+}
+
+// authorizeStream is authorizeUnary for streaming calls. Callers allowed
+// only for themselves have every message they send checked.
+func (s *server) authorizeStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ownerOnly, err := s.authorizeCaller(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	if ownerOnly {
+		stream = &ownerCheckedStream{ServerStream: stream, s: s, method: path.Base(info.FullMethod)}
+	}
+	return handler(srv, stream)
+}
+
+// ownerCheckedStream is a server stream that fails to receive messages on
+// behalf of anyone but the caller.
+type ownerCheckedStream struct {
+	grpc.ServerStream
+	s      *server
+	method string
+}
+
+func (w *ownerCheckedStream) RecvMsg(m interface{}) error {
+	if err := w.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return w.s.authorizeOwner(w.Context(), w.method, m)
+}
+
+// authorizeCaller checks the caller's roles against the rule of fullMethod.
+// It reports whether the caller may only act for themselves.
+func (s *server) authorizeCaller(ctx context.Context, fullMethod string) (bool, error) {
+	id := callerIdentity(ctx)
+	if id == nil {
+		return false, errorInfo(codes.Unauthenticated, reasonCredentialsMissing, nil, "credentials required")
+	}
+	method := path.Base(fullMethod)
+	rule := s.policy.rule(method)
+	if hasRole(id, rule.Roles) {
+		return false, nil
+	}
+	if hasRole(id, rule.OwnerRoles) {
+		return true, nil
+	}
+	return false, errorInfo(codes.PermissionDenied, reasonPermissionDenied, map[string]string{"method": method, "subject": id.subject},
+		"%s may not call %s", id.subject, method)
+}
+
+// authorizeOwner checks that msg, sent to method, acts only for the caller.
+// Messages that name nobody, such as a seat pick, are allowed.
+func (s *server) authorizeOwner(ctx context.Context, method string, msg interface{}) error {
+	id := callerIdentity(ctx)
+	s.mu.Lock()
+	owners, named, err := s.owners(method, msg)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if !named {
+		return nil
+	}
+	if id.email != "" && contains(owners, id.email) {
+		return nil
+	}
+	// Tickets that do not exist are refused like other people's, so callers
+	// cannot probe for receipt IDs
+	return errorInfo(codes.PermissionDenied, reasonNotOwner, map[string]string{"subject": id.subject},
+		"%s may only act on their own tickets", id.subject)
+}
+
+// owners returns the emails of whoever msg, sent to method, acts for: those
+// ticketOwners allows on the ticket it names, the booker the hold it names was
+// made for, the passenger of the waitlist entry, or the user or email it
+// gives. named is false if msg names none of these. The server lock must be
+// held.
+func (s *server) owners(method string, msg interface{}) (emails []string, named bool, err error) {
+	if m, ok := msg.(*pb.SeatSelectionRequest); ok {
+		if start := m.GetStart(); start != nil {
+			return []string{start.Email}, true, nil
+		}
+		if confirm := m.GetConfirm(); confirm != nil {
+			return s.owners(method, confirm)
+		}
+		return nil, false, nil
+	}
+	if m, ok := msg.(interface{ GetHoldId() string }); ok && m.GetHoldId() != "" {
+		// Like tickets, holds that do not exist are refused like other people's
+		hold, ok := s.holds[m.GetHoldId()]
+		if !ok {
+			return nil, true, nil
+		}
+		return []string{hold.reservedFor}, true, nil
+	}
+	if m, ok := msg.(*pb.ModifySeatRequest); ok && m.ReceiptId == "" && m.Email != "" {
+		// The ticket is found by email; if it cannot be, ModifySeat says why
+		receipt, err := s.resolveTicket("", m.Email)
+		if err != nil {
+			return []string{m.Email}, true, nil
+		}
+		return ticketOwners(method, msg, receipt), true, nil
+	}
+	if m, ok := msg.(interface{ GetReceiptId() string }); ok && m.GetReceiptId() != "" {
+		receipt, err := s.receipt(m.GetReceiptId())
+		if status.Code(err) == codes.NotFound {
+			return nil, true, nil
+		}
+		if err != nil {
+			return nil, true, err
+		}
+		return ticketOwners(method, msg, receipt), true, nil
+	}
+	if m, ok := msg.(interface{ GetEntryId() string }); ok {
+		entry, err := s.waitlistEntry(m.GetEntryId())
+		if err != nil {
+			return nil, true, nil
+		}
+		return []string{entry.user.GetEmail()}, true, nil
+	}
+	if m, ok := msg.(interface{ GetUser() *pb.User }); ok {
+		return []string{m.GetUser().GetEmail()}, true, nil
+	}
+	if m, ok := msg.(interface{ GetEmail() string }); ok {
+		return []string{m.GetEmail()}, true, nil
+	}
+	return nil, false, nil
+}
+
+// ticketOwners returns the emails that may act on receipt through msg, sent
+// to method. The booker always may. Passengers still travelling on it may
+// too, except for bookerMethods, and boarding passes only for their own seat.
+func ticketOwners(method string, msg interface{}, receipt *pb.ReceiptResponse) []string {
+	booker := receipt.GetUser().GetEmail()
+	if bookerMethods[method] {
+		return []string{booker}
+	}
+	if m, ok := msg.(*pb.GetBoardingPassRequest); ok {
+		line, err := seatLineFor(receipt, "seat", m.Seat)
+		if err != nil {
+			return []string{booker}
+		}
+		return []string{booker, line.GetPassenger().GetEmail()}
+	}
+	emails := []string{booker}
+	for _, line := range seatLines(receipt) {
+		emails = append(emails, line.GetPassenger().GetEmail())
+	}
+	return emails
+}
+
+// passengerReceipt returns what a passenger who is not its booker may see of
+// receipt: the journey and their own seats, without the fare, payment,
+// refunds or anyone else's seats. The booker gets receipt as it is. ok is
+// false if email is neither the booker nor still travelling on it.
+func passengerReceipt(receipt *pb.ReceiptResponse, email string) (own *pb.ReceiptResponse, ok bool) {
+	if receipt.GetUser().GetEmail() == email {
+		return receipt, true
+	}
+	own = &pb.ReceiptResponse{
+		From:        receipt.From,
+		To:          receipt.To,
+		User:        receipt.User,
+		DepartureId: receipt.DepartureId,
+		ReceiptId:   receipt.ReceiptId,
+		Status:      receipt.Status,
+	}
+	for _, line := range seatLines(receipt) {
+		if line.GetPassenger().GetEmail() == email {
+			own.SeatLines = append(own.SeatLines, line)
+		}
+	}
+	if len(own.SeatLines) == 0 {
+		return nil, false
+	}
+	own.Seat = own.SeatLines[0].Seat
+	own.Section = own.SeatLines[0].Section
+	return own, true
+}
+
+// hasRole reports whether id has any of roles.
+func hasRole(id *identity, roles []string) bool {
+	for _, role := range id.roles {
+		if contains(roles, role) {
+			return true
+		}
+	}
+	return false
+}
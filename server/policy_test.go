@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// authorize runs req through the unary policy interceptor as the caller
+// email with role, and returns why it was refused, if it was.
+func authorize(s *server, email, role, method string, req interface{}) (codes.Code, string) {
+	ctx := withIdentity(context.Background(), &identity{subject: email, email: email, roles: []string{role}})
+	_, err := s.authorizeUnary(ctx, req, &grpc.UnaryServerInfo{FullMethod: "/train.TicketService/" + method},
+		func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil })
+	_, reason := errorDetails(err)
+	return status.Code(err), reason
+}
+
+func TestAuthorizeUnary(t *testing.T) {
+	s := newTestServer()
+	own, err := purchaseWithToken(s, "john.doe@example.com", "A1", "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		email  string
+		role   string
+		method string
+		req    interface{}
+		code   codes.Code
+		reason string
+	}{
+		{"own receipt", "john.doe@example.com", rolePassenger, "GetReceipt", &pb.ReceiptRequest{ReceiptId: own.ReceiptId}, codes.OK, ""},
+		{"someone else's receipt", "jane.doe@example.com", rolePassenger, "GetReceipt", &pb.ReceiptRequest{ReceiptId: own.ReceiptId}, codes.PermissionDenied, reasonNotOwner},
+		{"missing receipt", "jane.doe@example.com", rolePassenger, "GetReceipt", &pb.ReceiptRequest{ReceiptId: "rec-99"}, codes.PermissionDenied, reasonNotOwner},
+		{"agent reads any receipt", "agent@example.com", roleAgent, "GetReceipt", &pb.ReceiptRequest{ReceiptId: own.ReceiptId}, codes.OK, ""},
+		{"move own seat", "john.doe@example.com", rolePassenger, "ModifySeat", &pb.ModifySeatRequest{ReceiptId: own.ReceiptId, NewSeat: "A2"}, codes.OK, ""},
+		{"move someone else's seat", "jane.doe@example.com", rolePassenger, "ModifySeat", &pb.ModifySeatRequest{ReceiptId: own.ReceiptId, NewSeat: "A2"}, codes.PermissionDenied, reasonNotOwner},
+		{"move seat by email", "jane.doe@example.com", rolePassenger, "ModifySeat", &pb.ModifySeatRequest{Email: "john.doe@example.com", NewSeat: "A2"}, codes.PermissionDenied, reasonNotOwner},
+		{"own tickets", "jane.doe@example.com", rolePassenger, "ListMyTickets", &pb.ListMyTicketsRequest{Email: "jane.doe@example.com"}, codes.OK, ""},
+		{"buy for someone else", "jane.doe@example.com", rolePassenger, "PurchaseTicket", &pb.PurchaseRequest{User: &pb.User{Email: "john.doe@example.com"}}, codes.PermissionDenied, reasonNotOwner},
+		{"passenger manifest", "john.doe@example.com", rolePassenger, "ViewUsersBySection", &pb.ViewUsersRequest{Section: "SectionA"}, codes.PermissionDenied, reasonPermissionDenied},
+		{"agent manifest", "agent@example.com", roleAgent, "ViewUsersBySection", &pb.ViewUsersRequest{Section: "SectionA"}, codes.OK, ""},
+		{"agent removes user", "agent@example.com", roleAgent, "RemoveUser", &pb.RemoveUserRequest{Email: "john.doe@example.com"}, codes.PermissionDenied, reasonPermissionDenied},
+		{"passenger removes self", "john.doe@example.com", rolePassenger, "RemoveUser", &pb.RemoveUserRequest{Email: "john.doe@example.com"}, codes.PermissionDenied, reasonPermissionDenied},
+		{"admin removes user", "admin@example.com", roleAdmin, "RemoveUser", &pb.RemoveUserRequest{Email: "john.doe@example.com"}, codes.OK, ""},
+		{"unknown role", "john.doe@example.com", "guest", "ListStations", &pb.ListStationsRequest{}, codes.PermissionDenied, reasonPermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, reason := authorize(s, tt.email, tt.role, tt.method, tt.req)
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, tt.reason, reason)
+		})
+	}
+
+	_, err = s.authorizeUnary(context.Background(), &pb.ListStationsRequest{}, &grpc.UnaryServerInfo{FullMethod: "/train.TicketService/ListStations"}, nil)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestAuthorizePartyPassengers(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	booker := &pb.User{Email: "jane.doe@example.com"}
+	party := []*pb.User{booker, {Email: "kid.doe@example.com"}, {Email: "gran.doe@example.com"}}
+	resp, err := s.PurchaseTicket(ctx, &pb.PurchaseRequest{From: "London", To: "Paris", DepartureId: testDepartureID, User: booker, Passengers: party})
+	require.NoError(t, err)
+	// Gran is no longer travelling
+	_, err = s.RemoveUser(ctx, &pb.RemoveUserRequest{Email: "gran.doe@example.com"})
+	require.NoError(t, err)
+	receiptID := resp.ReceiptId
+
+	tests := []struct {
+		name   string
+		email  string
+		method string
+		req    interface{}
+		code   codes.Code
+	}{
+		{"booker cancels", "jane.doe@example.com", "CancelTicket", &pb.CancelTicketRequest{ReceiptId: receiptID}, codes.OK},
+		{"co-passenger cancels", "kid.doe@example.com", "CancelTicket", &pb.CancelTicketRequest{ReceiptId: receiptID}, codes.PermissionDenied},
+		{"co-passenger moves a seat", "kid.doe@example.com", "ModifySeat", &pb.ModifySeatRequest{ReceiptId: receiptID, CurrentSeat: "A2", NewSeat: "B1"}, codes.PermissionDenied},
+		{"co-passenger moves a seat by email", "kid.doe@example.com", "ModifySeat", &pb.ModifySeatRequest{Email: "kid.doe@example.com", NewSeat: "B1"}, codes.PermissionDenied},
+		{"co-passenger renders the receipt", "kid.doe@example.com", "RenderReceipt", &pb.RenderReceiptRequest{ReceiptId: receiptID}, codes.PermissionDenied},
+		{"booker's boarding pass", "jane.doe@example.com", "GetBoardingPass", &pb.GetBoardingPassRequest{ReceiptId: receiptID, Seat: "A1"}, codes.OK},
+		{"booker gets the party's passes", "jane.doe@example.com", "GetBoardingPass", &pb.GetBoardingPassRequest{ReceiptId: receiptID, Seat: "A2"}, codes.OK},
+		{"co-passenger's own pass", "kid.doe@example.com", "GetBoardingPass", &pb.GetBoardingPassRequest{ReceiptId: receiptID, Seat: "A2"}, codes.OK},
+		{"co-passenger's pass for another seat", "kid.doe@example.com", "GetBoardingPass", &pb.GetBoardingPassRequest{ReceiptId: receiptID, Seat: "A1"}, codes.PermissionDenied},
+		{"co-passenger's pass without a seat", "kid.doe@example.com", "GetBoardingPass", &pb.GetBoardingPassRequest{ReceiptId: receiptID}, codes.PermissionDenied},
+		{"co-passenger's receipt", "kid.doe@example.com", "GetReceipt", &pb.ReceiptRequest{ReceiptId: receiptID}, codes.OK},
+		{"cancelled passenger's receipt", "gran.doe@example.com", "GetReceipt", &pb.ReceiptRequest{ReceiptId: receiptID}, codes.PermissionDenied},
+		{"cancelled passenger's old seat", "gran.doe@example.com", "GetBoardingPass", &pb.GetBoardingPassRequest{ReceiptId: receiptID, Seat: "A3"}, codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, _ := authorize(s, tt.email, rolePassenger, tt.method, tt.req)
+			assert.Equal(t, tt.code, code)
+		})
+	}
+
+	// A co-passenger sees only their own seat on the receipt
+	get := func(email string) *pb.ReceiptResponse {
+		ctx := withIdentity(ctx, &identity{subject: email, email: email, roles: []string{rolePassenger}})
+		resp, err := s.authorizeUnary(ctx, &pb.ReceiptRequest{ReceiptId: receiptID}, &grpc.UnaryServerInfo{FullMethod: "/train.TicketService/GetReceipt"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return s.GetReceipt(ctx, req.(*pb.ReceiptRequest))
+			})
+		require.NoError(t, err)
+		return resp.(*pb.ReceiptResponse)
+	}
+	own := get("kid.doe@example.com")
+	require.Len(t, own.SeatLines, 1)
+	assert.Equal(t, "kid.doe@example.com", own.SeatLines[0].Passenger.Email)
+	assert.Equal(t, "A2", own.Seat)
+	assert.Nil(t, own.PricePaid)
+	assert.Empty(t, own.CancelledSeatLines)
+	assert.Empty(t, own.Refunds)
+	full := get("jane.doe@example.com")
+	assert.Len(t, full.SeatLines, 2)
+	assert.Len(t, full.CancelledSeatLines, 1)
+	assert.NotNil(t, full.PricePaid)
+
+	// and so does their list of tickets, which leaves out bookings they no
+	// longer travel on
+	list := func(email string) *pb.ListMyTicketsResponse {
+		ctx := withIdentity(ctx, &identity{subject: email, email: email, roles: []string{rolePassenger}})
+		resp, err := s.authorizeUnary(ctx, &pb.ListMyTicketsRequest{Email: email}, &grpc.UnaryServerInfo{FullMethod: "/train.TicketService/ListMyTickets"},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				return s.ListMyTickets(ctx, req.(*pb.ListMyTicketsRequest))
+			})
+		require.NoError(t, err)
+		return resp.(*pb.ListMyTicketsResponse)
+	}
+	tickets := list("kid.doe@example.com")
+	require.Len(t, tickets.Receipts, 1)
+	assert.EqualValues(t, 1, tickets.TotalSize)
+	own = tickets.Receipts[0]
+	require.Len(t, own.SeatLines, 1)
+	assert.Equal(t, "kid.doe@example.com", own.SeatLines[0].Passenger.Email)
+	assert.Nil(t, own.PricePaid)
+	assert.Empty(t, own.PaymentId)
+	assert.Empty(t, own.CancelledSeatLines)
+	assert.Empty(t, own.Refunds)
+	tickets = list("gran.doe@example.com")
+	assert.Empty(t, tickets.Receipts)
+	assert.Zero(t, tickets.TotalSize)
+	tickets = list("jane.doe@example.com")
+	require.Len(t, tickets.Receipts, 1)
+	assert.Len(t, tickets.Receipts[0].CancelledSeatLines, 1)
+	assert.NotEmpty(t, tickets.Receipts[0].PaymentId)
+}
+
+func TestAuthorizeHolds(t *testing.T) {
+	s := newTestServer()
+	holdResp, err := s.HoldSeats(context.Background(), &pb.HoldSeatsRequest{DepartureId: testDepartureID, Seats: []string{"A1"}, Email: "jane.doe@example.com"})
+	require.NoError(t, err)
+	holdID := holdResp.Hold.HoldId
+
+	tests := []struct {
+		name   string
+		email  string
+		method string
+		req    interface{}
+		code   codes.Code
+	}{
+		{"hold for self", "jane.doe@example.com", "HoldSeats", &pb.HoldSeatsRequest{DepartureId: testDepartureID, Seats: []string{"A2"}, Email: "jane.doe@example.com"}, codes.OK},
+		{"hold for someone else", "john.doe@example.com", "HoldSeats", &pb.HoldSeatsRequest{DepartureId: testDepartureID, Seats: []string{"A2"}, Email: "jane.doe@example.com"}, codes.PermissionDenied},
+		{"release own hold", "jane.doe@example.com", "ReleaseHold", &pb.ReleaseHoldRequest{HoldId: holdID, Email: "jane.doe@example.com"}, codes.OK},
+		{"release someone else's hold", "john.doe@example.com", "ReleaseHold", &pb.ReleaseHoldRequest{HoldId: holdID, Email: "john.doe@example.com"}, codes.PermissionDenied},
+		{"release missing hold", "jane.doe@example.com", "ReleaseHold", &pb.ReleaseHoldRequest{HoldId: "hold-missing", Email: "jane.doe@example.com"}, codes.PermissionDenied},
+		{"buy own hold", "jane.doe@example.com", "PurchaseTicket", &pb.PurchaseRequest{User: &pb.User{Email: "jane.doe@example.com"}, HoldId: holdID}, codes.OK},
+		{"buy someone else's hold", "john.doe@example.com", "PurchaseTicket", &pb.PurchaseRequest{User: &pb.User{Email: "john.doe@example.com"}, HoldId: holdID}, codes.PermissionDenied},
+		{"agent releases any hold", "agent@example.com", "ReleaseHold", &pb.ReleaseHoldRequest{HoldId: holdID, Email: "jane.doe@example.com"}, codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role := rolePassenger
+			if tt.email == "agent@example.com" {
+				role = roleAgent
+			}
+			code, _ := authorize(s, tt.email, role, tt.method, tt.req)
+			assert.Equal(t, tt.code, code)
+		})
+	}
+
+	// Selection sessions hold seats for the email they start with
+	ctx := withIdentity(context.Background(), &identity{subject: "john", email: "john.doe@example.com", roles: []string{rolePassenger}})
+	stream := &recvStream{ctx: ctx, msgs: []proto.Message{
+		&pb.SeatSelectionRequest{Action: &pb.SeatSelectionRequest_Start{Start: &pb.StartSelection{DepartureId: testDepartureID, Email: "jane.doe@example.com"}}},
+	}}
+	err = s.authorizeStream(nil, stream, &grpc.StreamServerInfo{FullMethod: "/train.TicketService/SeatSelectionSession"},
+		func(srv interface{}, stream grpc.ServerStream) error {
+			return stream.RecvMsg(&pb.SeatSelectionRequest{})
+		})
+	_, reason := errorDetails(err)
+	assert.Equal(t, reasonNotOwner, reason)
+}
+
+// recvStream is a server stream that receives msgs in turn.
+type recvStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	msgs []proto.Message
+}
+
+func (r *recvStream) Context() context.Context { return r.ctx }
+
+func (r *recvStream) RecvMsg(m interface{}) error {
+	if len(r.msgs) == 0 {
+		return io.EOF
+	}
+	proto.Merge(m.(proto.Message), r.msgs[0])
+	r.msgs = r.msgs[1:]
+	return nil
+}
+
+func TestAuthorizeStream(t *testing.T) {
+	s := newTestServer()
+	ctx := withIdentity(context.Background(), &identity{subject: "jane", email: "jane.doe@example.com", roles: []string{rolePassenger}})
+	info := &grpc.StreamServerInfo{FullMethod: "/train.TicketService/SeatSelectionSession"}
+
+	// Picks name nobody; confirming for someone else is refused
+	stream := &recvStream{ctx: ctx, msgs: []proto.Message{
+		pick("A1"),
+		&pb.SeatSelectionRequest{Action: &pb.SeatSelectionRequest_Confirm{Confirm: &pb.ConfirmSelection{User: &pb.User{Email: "john.doe@example.com"}}}},
+	}}
+	err := s.authorizeStream(nil, stream, info, func(srv interface{}, stream grpc.ServerStream) error {
+		for {
+			if err := stream.RecvMsg(&pb.SeatSelectionRequest{}); err != nil {
+				return err
+			}
+		}
+	})
+	_, reason := errorDetails(err)
+	assert.Equal(t, reasonNotOwner, reason)
+
+	err = s.authorizeStream(nil, &recvStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/train.TicketService/BulkPurchase"},
+		func(srv interface{}, stream grpc.ServerStream) error { return nil })
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
+
+func TestLoadAccessPolicy(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "policy.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"default": {"roles": ["staff"]},
+		"methods": {"GetReceipt": {"roles": ["staff"], "owner_roles": ["customer"]}}
+	}`), 0o600))
+	p, err := loadAccessPolicy(path)
+	require.NoError(t, err)
+	assert.Equal(t, accessRule{Roles: []string{"staff"}, OwnerRoles: []string{"customer"}}, p.rule("GetReceipt"))
+	assert.Equal(t, accessRule{Roles: []string{"staff"}}, p.rule("RemoveUser"))
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"methods": {"DeleteEverything": {"roles": ["admin"]}}}`), 0o600))
+	_, err = loadAccessPolicy(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/Aravinthvvs/gRPC/receipt"
+)
+
+func (s *server) RenderReceipt(ctx context.Context, req *pb.RenderReceiptRequest) (*pb.RenderReceiptResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.ReceiptId == "" {
+		return nil, badRequest(fieldViolation("receipt_id", "receipt_id is required"))
+	}
+	r, err := s.resolveTicket(req.ReceiptId, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = receipt.Render(&buf, s.receiptDocument(r), req.Format)
+	if errors.Is(err, receipt.ErrUnknownFormat) {
+		return nil, badRequest(fieldViolation("format", fmt.Sprintf("unknown receipt format %v", req.Format)))
+	}
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	return &pb.RenderReceiptResponse{
+		Content:     buf.Bytes(),
+		ContentType: receipt.ContentType(req.Format),
+		Filename:    r.ReceiptId + receipt.Extension(req.Format),
+	}, nil
+}
+
+// receiptDocument fills in the journey details r refers to from the catalog.
+func (s *server) receiptDocument(r *pb.ReceiptResponse) *receipt.Document {
+	d := &receipt.Document{Receipt: r, From: r.From, To: r.To}
+	if from, ok := s.catalog.station(r.From); ok {
+		d.From = from.Name
+	}
+	if to, ok := s.catalog.station(r.To); ok {
+		d.To = to.Name
+	}
+	if dep, ok := s.catalog.departure(r.DepartureId); ok {
+		d.Train = dep.Train.Name
+		d.DepartsAt = s.boardingTime(r)
+	}
+	return d
+}
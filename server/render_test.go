@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRenderReceipt(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	resp, err := s.PurchaseTicket(ctx, &pb.PurchaseRequest{
+		From:        "London",
+		To:          "Paris",
+		DepartureId: testDepartureID,
+		User:        &pb.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
+	})
+	require.NoError(t, err)
+
+	text, err := s.RenderReceipt(ctx, &pb.RenderReceiptRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=utf-8", text.ContentType)
+	assert.Equal(t, resp.ReceiptId+".txt", text.Filename)
+	assert.Contains(t, string(text.Content), "Departure: ES9010-20300901 (Eurostar 9010)")
+	assert.Contains(t, string(text.Content), "John Doe")
+
+	pdf, err := s.RenderReceipt(ctx, &pb.RenderReceiptRequest{ReceiptId: resp.ReceiptId, Format: pb.ReceiptFormat_RECEIPT_FORMAT_PDF})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(pdf.Content), "%PDF-"))
+	assert.Equal(t, resp.ReceiptId+".pdf", pdf.Filename)
+
+	_, err = s.RenderReceipt(ctx, &pb.RenderReceiptRequest{ReceiptId: resp.ReceiptId, Format: pb.ReceiptFormat(42)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.RenderReceipt(ctx, &pb.RenderReceiptRequest{ReceiptId: "rec-404"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+)
+
+// sectionLayout describes one section of a train: its seats are named
+// Prefix+1 … Prefix+Capacity, e.g. A1 … A20.
+type sectionLayout struct {
+	Name     string `json:"name"`
+	Prefix   string `json:"prefix"`
+	Capacity int    `json:"capacity"`
+}
+
+// defaultSections is the layout used by trains that do not declare their own.
+func defaultSections() []*sectionLayout {
+	return []*sectionLayout{
+		{Name: "SectionA", Prefix: "A", Capacity: 20},
+		{Name: "SectionB", Prefix: "B", Capacity: 20},
+	}
+}
+
+// seatIDs returns the section's seats in allocation order.
+func (l *sectionLayout) seatIDs() []string {
+	seats := make([]string, l.Capacity)
+	for i := range seats {
+		seats[i] = l.Prefix + strconv.Itoa(i+1)
+	}
+	return seats
+}
+
+// hasSeat reports whether seat belongs to the section.
+func (l *sectionLayout) hasSeat(seat string) bool {
+	if !strings.HasPrefix(seat, l.Prefix) {
+		return false
+	}
+	n, err := strconv.Atoi(seat[len(l.Prefix):])
+	return err == nil && n >= 1 && n <= l.Capacity && seat == l.Prefix+strconv.Itoa(n)
+}
+
+// seatLess orders seats by their letter prefix and then numerically, so A2
+// comes before A10.
+func seatLess(a, b string) bool {
+	ap, an := splitSeat(a)
+	bp, bn := splitSeat(b)
+	if ap != bp {
+		return ap < bp
+	}
+	if an != bn {
+		return an < bn
+	}
+	return a < b
+}
+
+// splitSeat splits a seat ID into its prefix and number, e.g. "A12" into "A"
+// and 12.
+func splitSeat(seat string) (string, int) {
+	i := len(seat)
+	for i > 0 && seat[i-1] >= '0' && seat[i-1] <= '9' {
+		i--
+	}
+	n, _ := strconv.Atoi(seat[i:])
+	return seat[:i], n
+}
+
+// seatMap is the occupancy of every seat on a departure.
+type seatMap struct {
+	departure *departure
+	occupied  map[string]string // seat ID → receipt or hold ID
+}
+
+// seatMap loads the current occupancy of dep. Seats of bookings still being
+// paid for count as taken by their receipt ID, and seats on a live hold by
+// the hold's ID; neither is in the store.
+func (s *server) seatMap(dep *departure) (*seatMap, error) {
+	occupied, err := s.store.OccupiedSeats(dep.ID)
+	if err != nil {
+		return nil, err
+	}
+	for receiptID, receipt := range s.pending {
+		if receipt.DepartureId != dep.ID {
+			continue
+		}
+		for _, line := range receipt.SeatLines {
+			occupied[line.Seat] = receiptID
+		}
+	}
+	now := s.now()
+	for _, hold := range s.holds {
+		if hold.departureID != dep.ID || hold.expired(now) {
+			continue
+		}
+		for _, seat := range hold.seats {
+			if _, taken := occupied[seat]; !taken {
+				occupied[seat] = hold.id
+			}
+		}
+	}
+	return &seatMap{departure: dep, occupied: occupied}, nil
+}
+
+// section returns the layout of the named section.
+func (m *seatMap) section(name string) (*sectionLayout, bool) {
+	for _, l := range m.departure.Train.Sections {
+		if l.Name == name {
+			return l, true
+		}
+	}
+	return nil, false
+}
+
+// sectionOf returns the section that seat belongs to.
+func (m *seatMap) sectionOf(seat string) (*sectionLayout, bool) {
+	for _, l := range m.departure.Train.Sections {
+		if l.hasSeat(seat) {
+			return l, true
+		}
+	}
+	return nil, false
+}
+
+// freeSeats returns the unoccupied seats in section in allocation order.
+func (m *seatMap) freeSeats(section *sectionLayout) []string {
+	var free []string
+	for _, seat := range section.seatIDs() {
+		if _, taken := m.occupied[seat]; !taken {
+			free = append(free, seat)
+		}
+	}
+	return free
+}
+
+// allocateParty returns n free seats for a party travelling together. It
+// prefers n adjacent seats in one section, trying preferred first, then any n
+// seats in one section, and finally seats spread over several sections. It
+// fails with RESOURCE_EXHAUSTED if the departure has fewer than n free seats.
+func (m *seatMap) allocateParty(preferred *sectionLayout, n int) ([]string, error) {
+	sections := []*sectionLayout{preferred}
+	for _, l := range m.departure.Train.Sections {
+		if l != preferred {
+			sections = append(sections, l)
+		}
+	}
+
+	for _, l := range sections {
+		if run := m.adjacentSeats(l, n); run != nil {
+			return run, nil
+		}
+	}
+	for _, l := range sections {
+		if free := m.freeSeats(l); len(free) >= n {
+			return free[:n], nil
+		}
+	}
+	var free []string
+	for _, l := range sections {
+		free = append(free, m.freeSeats(l)...)
+	}
+	if len(free) >= n {
+		return free[:n], nil
+	}
+
+	return nil, errorInfo(codes.ResourceExhausted, reasonNotEnoughSeats,
+		map[string]string{"departure_id": m.departure.ID, "requested": strconv.Itoa(n), "available": strconv.Itoa(len(free))},
+		"departure %s has %d free seats, %d requested", m.departure.ID, len(free), n)
+}
+
+// adjacentSeats returns the first run of n consecutive free seats in section,
+// or nil if there is none.
+func (m *seatMap) adjacentSeats(section *sectionLayout, n int) []string {
+	var run []string
+	for _, seat := range section.seatIDs() {
+		if _, taken := m.occupied[seat]; taken {
+			run = nil
+			continue
+		}
+		run = append(run, seat)
+		if len(run) == n {
+			return run
+		}
+	}
+	return nil
+}
+
+// claim checks that seat exists on the departure and is free (or already held
+// by the ticket receiptID) and returns the section it belongs to. It fails
+// with FAILED_PRECONDITION otherwise.
+func (m *seatMap) claim(seat, receiptID string) (*sectionLayout, error) {
+	section, ok := m.sectionOf(seat)
+	if !ok {
+		return nil, errorInfo(codes.FailedPrecondition, reasonSeatNotFound,
+			map[string]string{"departure_id": m.departure.ID, "seat": seat},
+			"seat %s does not exist on departure %s", seat, m.departure.ID)
+	}
+	if occupant, taken := m.occupied[seat]; taken && occupant != receiptID {
+		return nil, errorInfo(codes.FailedPrecondition, reasonSeatTaken,
+			map[string]string{"departure_id": m.departure.ID, "seat": seat},
+			"seat %s on departure %s is already taken", seat, m.departure.ID)
+	}
+	return section, nil
+}
+
+// openSections returns the sections of the departure with at least one free seat.
+func (m *seatMap) openSections() []*sectionLayout {
+	var open []*sectionLayout
+	for _, l := range m.departure.Train.Sections {
+		if len(m.freeSeats(l)) > 0 {
+			open = append(open, l)
+		}
+	}
+	return open
+}
+
+// soldOut reports whether every seat on the departure is taken.
+func (m *seatMap) soldOut() bool {
+	return len(m.openSections()) == 0
+}
+
+// validateSections checks a train's section layout.
+func validateSections(trainID string, sections []*sectionLayout) error {
+	names := make(map[string]bool)
+	var prefixes []string
+	for _, l := range sections {
+		if l.Name != "SectionA" && l.Name != "SectionB" {
+			return fmt.Errorf("train %q has unsupported section %q", trainID, l.Name)
+		}
+		if names[l.Name] {
+			return fmt.Errorf("train %q declares section %q twice", trainID, l.Name)
+		}
+		names[l.Name] = true
+		if l.Prefix == "" || l.Capacity <= 0 {
+			return fmt.Errorf("train %q section %q needs a seat prefix and a positive capacity", trainID, l.Name)
+		}
+		// A seat must name exactly one section: with "A" and "A1", or "A"
+		// ending in a digit, A11 could be read more than one way
+		if last := l.Prefix[len(l.Prefix)-1]; last >= '0' && last <= '9' {
+			return fmt.Errorf("train %q section %q seat prefix %q must not end in a digit", trainID, l.Name, l.Prefix)
+		}
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(l.Prefix, prefix) || strings.HasPrefix(prefix, l.Prefix) {
+				return fmt.Errorf("train %q seat prefixes %q and %q overlap", trainID, prefix, l.Prefix)
+			}
+		}
+		prefixes = append(prefixes, l.Prefix)
+	}
+	return nil
+}
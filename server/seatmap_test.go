@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newSmallTrainServer returns a test server whose ES9010 has two seats per section.
+func newSmallTrainServer() *server {
+	s := newTestServer()
+	s.catalog.trainsByID["ES9010"].Sections = []*sectionLayout{
+		{Name: "SectionA", Prefix: "A", Capacity: 2},
+		{Name: "SectionB", Prefix: "B", Capacity: 2},
+	}
+	return s
+}
+
+func TestSectionLayoutSeats(t *testing.T) {
+	l := &sectionLayout{Name: "SectionA", Prefix: "A", Capacity: 3}
+	assert.Equal(t, []string{"A1", "A2", "A3"}, l.seatIDs())
+
+	for _, seat := range []string{"A1", "A3"} {
+		assert.True(t, l.hasSeat(seat), seat)
+	}
+	for _, seat := range []string{"A0", "A4", "A01", "B1", "A", "Seat-1"} {
+		assert.False(t, l.hasSeat(seat), seat)
+	}
+}
+
+func TestValidateSections(t *testing.T) {
+	tests := []struct {
+		name     string
+		sections []*sectionLayout
+		valid    bool
+	}{
+		{"default", defaultSections(), true},
+		{"multi-letter prefixes", []*sectionLayout{{Name: "SectionA", Prefix: "FC", Capacity: 10}, {Name: "SectionB", Prefix: "SC", Capacity: 10}}, true},
+		{"unsupported section", []*sectionLayout{{Name: "SectionC", Prefix: "C", Capacity: 10}}, false},
+		{"section twice", []*sectionLayout{{Name: "SectionA", Prefix: "A", Capacity: 10}, {Name: "SectionA", Prefix: "B", Capacity: 10}}, false},
+		{"no capacity", []*sectionLayout{{Name: "SectionA", Prefix: "A"}}, false},
+		{"same prefix", []*sectionLayout{{Name: "SectionA", Prefix: "A", Capacity: 10}, {Name: "SectionB", Prefix: "A", Capacity: 10}}, false},
+		{"prefix extends another", []*sectionLayout{{Name: "SectionA", Prefix: "A", Capacity: 20}, {Name: "SectionB", Prefix: "AB", Capacity: 20}}, false},
+		{"prefix extended by another", []*sectionLayout{{Name: "SectionA", Prefix: "AB", Capacity: 20}, {Name: "SectionB", Prefix: "A", Capacity: 20}}, false},
+		{"prefix ends in a digit", []*sectionLayout{{Name: "SectionA", Prefix: "A", Capacity: 20}, {Name: "SectionB", Prefix: "A1", Capacity: 20}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSections("ES9010", tt.sections)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestPurchaseTicketSellsOut(t *testing.T) {
+	s := newSmallTrainServer()
+
+	taken := make(map[string]bool)
+	for i := 0; i < 4; i++ {
+		resp, err := s.PurchaseTicket(context.Background(), &pb.PurchaseRequest{
+			From:        "London",
+			To:          "Paris",
+			DepartureId: testDepartureID,
+			User:        &pb.User{Email: fmt.Sprintf("passenger%d@example.com", i)},
+		})
+		require.NoError(t, err)
+
+		receipt, err := s.GetReceipt(context.Background(), &pb.ReceiptRequest{ReceiptId: resp.ReceiptId})
+		require.NoError(t, err)
+		assert.Contains(t, []string{"A1", "A2", "B1", "B2"}, receipt.Seat)
+		assert.False(t, taken[receipt.Seat], "seat %s sold twice", receipt.Seat)
+		taken[receipt.Seat] = true
+	}
+
+	_, err := s.PurchaseTicket(context.Background(), &pb.PurchaseRequest{
+		From:        "London",
+		To:          "Paris",
+		DepartureId: testDepartureID,
+		User:        &pb.User{Email: "late@example.com"},
+	})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Another departure of the same train is unaffected.
+	_, err = s.PurchaseTicket(context.Background(), &pb.PurchaseRequest{
+		From:        "London",
+		To:          "Paris",
+		DepartureId: "ES9010-20300902",
+		User:        &pb.User{Email: "late@example.com"},
+	})
+	assert.NoError(t, err)
+}
+
+func TestRemoveUserFreesSeat(t *testing.T) {
+	s := newSmallTrainServer()
+
+	for i := 0; i < 4; i++ {
+		_, err := s.PurchaseTicket(context.Background(), &pb.PurchaseRequest{
+			From:        "London",
+			To:          "Paris",
+			DepartureId: testDepartureID,
+			User:        &pb.User{Email: fmt.Sprintf("passenger%d@example.com", i)},
+		})
+		require.NoError(t, err)
+	}
+
+	removeResp, err := s.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: "passenger2@example.com"})
+	require.NoError(t, err)
+	require.True(t, removeResp.Success)
+
+	_, err = s.PurchaseTicket(context.Background(), &pb.PurchaseRequest{
+		From:        "London",
+		To:          "Paris",
+		DepartureId: testDepartureID,
+		User:        &pb.User{Email: "late@example.com"},
+	})
+	assert.NoError(t, err)
+}
+
+// occupySeats stores a ticket holding seats on testDepartureID.
+func occupySeats(t *testing.T, s *server, receiptID string, seats ...string) {
+	dep, ok := s.catalog.departure(testDepartureID)
+	require.True(t, ok)
+	receipt := &pb.ReceiptResponse{ReceiptId: receiptID, DepartureId: dep.ID, User: &pb.User{Email: receiptID + "@example.com"}}
+	for _, seat := range seats {
+		for _, l := range dep.Train.Sections {
+			if l.hasSeat(seat) {
+				receipt.SeatLines = append(receipt.SeatLines, &pb.SeatLine{Seat: seat, Section: l.Name})
+			}
+		}
+	}
+	require.NoError(t, s.store.SaveTicket(receipt, nil))
+}
+
+func TestSeatMapAllocateParty(t *testing.T) {
+	s := newServer(newMemoryStore())
+	dep, ok := s.catalog.departure(testDepartureID)
+	require.True(t, ok)
+	occupySeats(t, s, "rec-1", "A2", "A5")
+
+	seats, err := s.seatMap(dep)
+	require.NoError(t, err)
+	sectionA, _ := seats.section("SectionA")
+	assigned, err := seats.allocateParty(sectionA, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A6", "A7", "A8"}, assigned)
+
+	// A party too large for any run of free seats is split up rather than refused.
+	occupySeats(t, s, "rec-2", sectionA.seatIDs()...)
+	sectionB, _ := seats.section("SectionB")
+	occupySeats(t, s, "rec-3", sectionB.seatIDs()[1:19]...)
+	seats, err = s.seatMap(dep)
+	require.NoError(t, err)
+	assigned, err = seats.allocateParty(sectionA, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"B1", "B20"}, assigned)
+
+	_, err = seats.allocateParty(sectionA, 3)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, reason := errorDetails(err)
+	assert.Equal(t, reasonNotEnoughSeats, reason)
+}
+
+func TestPurchaseTicketPreferredSeat(t *testing.T) {
+	s := newSmallTrainServer()
+	purchase := func(email, section, seat string) (*pb.ReceiptResponse, error) {
+		resp, err := s.PurchaseTicket(context.Background(), &pb.PurchaseRequest{
+			From:             "London",
+			To:               "Paris",
+			DepartureId:      testDepartureID,
+			User:             &pb.User{Email: email},
+			PreferredSection: section,
+			PreferredSeat:    seat,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return s.GetReceipt(context.Background(), &pb.ReceiptRequest{ReceiptId: resp.ReceiptId})
+	}
+
+	receipt, err := purchase("a@example.com", "", "B2")
+	require.NoError(t, err)
+	assert.Equal(t, "B2", receipt.Seat)
+	assert.Equal(t, "SectionB", receipt.Section)
+
+	_, err = purchase("b@example.com", "", "B2")
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = purchase("b@example.com", "", "C1")
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = purchase("b@example.com", "SectionA", "B1")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestModifySeatValidatesTarget(t *testing.T) {
+	s := newSmallTrainServer()
+	s.allocator = fillFirstAllocator{}
+	seats := purchaseSeats(t, s, "", "")
+	require.Equal(t, []string{"A1", "A2"}, seats)
+
+	tests := []struct {
+		name    string
+		newSeat string
+		code    codes.Code
+	}{
+		{"seat does not exist", "Seat-42", codes.FailedPrecondition},
+		{"seat beyond capacity", "A3", codes.FailedPrecondition},
+		{"seat taken by someone else", "A2", codes.FailedPrecondition},
+		{"keep own seat", "A1", codes.OK},
+		{"move to the other section", "B2", codes.OK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.ModifySeat(context.Background(), &pb.ModifySeatRequest{Email: "passenger0@example.com", NewSeat: tt.newSeat})
+			assert.Equal(t, tt.code, status.Code(err))
+			if tt.code == codes.OK {
+				assert.True(t, resp.Success)
+			}
+		})
+	}
+
+	sectionA, err := s.ViewUsersBySection(context.Background(), &pb.ViewUsersRequest{Section: "SectionA"})
+	require.NoError(t, err)
+	require.Len(t, sectionA.UserSeats, 1)
+	assert.Equal(t, "A2", sectionA.UserSeats[0].Seat)
+
+	sectionB, err := s.ViewUsersBySection(context.Background(), &pb.ViewUsersRequest{Section: "SectionB"})
+	require.NoError(t, err)
+	require.Len(t, sectionB.UserSeats, 1)
+	assert.Equal(t, "passenger0@example.com", sectionB.UserSeats[0].User.Email)
+	assert.Equal(t, "B2", sectionB.UserSeats[0].Seat)
+
+	dep, _ := s.catalog.departure(testDepartureID)
+	occupied, err := s.seatMap(dep)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"A2": "rec-2", "B2": "rec-1"}, occupied.occupied)
+
+	// The receipt follows the passenger to the new seat.
+	receipt, err := s.GetReceipt(context.Background(), &pb.ReceiptRequest{ReceiptId: "rec-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "B2", receipt.Seat)
+	assert.Equal(t, "SectionB", receipt.Section)
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// seatFeedLength is how many changes a departure's feed keeps for watchers
+// that reconnect. Watchers further behind start again from a snapshot.
+const seatFeedLength = 1024
+
+// seatFeedIdle is how long a feed is kept after its last watcher leaves, so
+// that a watcher whose connection dropped can still resume from its token.
+const seatFeedIdle = time.Minute
+
+var errInvalidResumeToken = errors.New("invalid resume token")
+
+// seatFeed records the changes to seat availability on a departure. Feeds are
+// started by the first watcher of a departure and dropped once nobody has
+// watched it for s.seatFeedIdle.
+type seatFeed struct {
+	departureID string
+	// epoch tells the resume tokens of this feed apart from those of earlier
+	// feeds of the same departure.
+	epoch string
+	// watchers counts the open watches; idle drops the feed once the last
+	// one has been gone long enough.
+	watchers int
+	idle     *time.Timer
+	// states is the state of every seat as of the last event.
+	states map[string]pb.SeatState
+	// events are the most recent changes, oldest first; the last one is
+	// number seq.
+	events []*pb.SeatMapEvent
+	seq    int64
+	// changed is closed and replaced whenever an event is added.
+	changed chan struct{}
+}
+
+func (s *server) WatchSeatMap(req *pb.WatchSeatMapRequest, stream pb.TicketService_WatchSeatMapServer) error {
+	if req.DepartureId == "" {
+		return badRequest(fieldViolation("departure_id", "departure_id is required"))
+	}
+	dep, ok := s.catalog.departure(req.DepartureId)
+	if !ok {
+		return errorInfo(codes.NotFound, reasonDepartureNotFound, map[string]string{"departure_id": req.DepartureId},
+			"departure %q not found", req.DepartureId)
+	}
+
+	s.mu.Lock()
+	feed, err := s.seatFeed(dep)
+	if err != nil {
+		s.mu.Unlock()
+		return storeError(err)
+	}
+	defer s.leaveSeatFeed(feed)
+	// A watch that cannot resume starts from a snapshot
+	after := int64(-1)
+	if req.ResumeToken != "" {
+		seq, resumable, err := feed.parseResumeToken(req.ResumeToken)
+		if err != nil {
+			s.mu.Unlock()
+			return badRequest(fieldViolation("resume_token", err.Error()))
+		}
+		if resumable && seq <= feed.seq {
+			after = seq
+		}
+	}
+	s.mu.Unlock()
+
+	for {
+		s.mu.Lock()
+		events := s.seatEventsSince(dep, feed, &after)
+		changed := feed.changed
+		s.mu.Unlock()
+
+		for _, event := range events {
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-changed:
+		}
+	}
+}
+
+// seatEventsSince returns the events of feed after event number *after and
+// moves *after to the latest event. If those events are no longer kept, or
+// *after is -1, it returns a snapshot instead.
+func (s *server) seatEventsSince(dep *departure, feed *seatFeed, after *int64) []*pb.SeatMapEvent {
+	missed := int(feed.seq - *after)
+	*after = feed.seq
+	if missed > len(feed.events) {
+		// Changes this far back are gone, so start over from the seats as
+		// they are now
+		return []*pb.SeatMapEvent{s.seatSnapshot(dep, feed)}
+	}
+	return feed.events[len(feed.events)-missed:]
+}
+
+// seatFeed returns the feed of dep, starting it if nobody has watched the
+// departure lately, and counts the caller as one of its watchers. Each
+// watcher must call leaveSeatFeed when it is done.
+func (s *server) seatFeed(dep *departure) (*seatFeed, error) {
+	if feed, ok := s.seatFeeds[dep.ID]; ok {
+		// Catch up with anything that changed without being published
+		s.publishSeats(dep.ID)
+		if feed.idle != nil {
+			feed.idle.Stop()
+			feed.idle = nil
+		}
+		feed.watchers++
+		return feed, nil
+	}
+	seats, err := s.seatStatuses(dep)
+	if err != nil {
+		return nil, err
+	}
+	s.nextSeatFeed++
+	feed := &seatFeed{
+		departureID: dep.ID,
+		epoch:       s.feedEpoch + "-" + strconv.Itoa(s.nextSeatFeed),
+		watchers:    1,
+		states:      make(map[string]pb.SeatState),
+		changed:     make(chan struct{}),
+	}
+	for _, seat := range seats {
+		feed.states[seat.Seat] = seat.State
+	}
+	s.seatFeeds[dep.ID] = feed
+	return feed, nil
+}
+
+// leaveSeatFeed stops counting a watcher of feed. When the last one leaves,
+// the feed is dropped after s.seatFeedIdle unless somebody watches the
+// departure again in the meantime.
+func (s *server) leaveSeatFeed(feed *seatFeed) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	feed.watchers--
+	if feed.watchers > 0 {
+		return
+	}
+	drop := func() {
+		if s.seatFeeds[feed.departureID] == feed && feed.watchers == 0 {
+			delete(s.seatFeeds, feed.departureID)
+		}
+	}
+	if s.seatFeedIdle <= 0 {
+		drop()
+		return
+	}
+	feed.idle = time.AfterFunc(s.seatFeedIdle, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		drop()
+	})
+}
+
+// seatSnapshot returns an event holding every seat of dep as feed last saw
+// them.
+func (s *server) seatSnapshot(dep *departure, feed *seatFeed) *pb.SeatMapEvent {
+	event := &pb.SeatMapEvent{
+		Snapshot:    true,
+		ResumeToken: feed.resumeToken(feed.seq),
+		Time:        timestamppb.New(s.now()),
+	}
+	for _, l := range dep.Train.Sections {
+		for _, seat := range l.seatIDs() {
+			event.Seats = append(event.Seats, &pb.SeatStatus{Seat: seat, Section: l.Name, State: feed.states[seat]})
+		}
+	}
+	return event
+}
+
+// publishSeats sends watchers of departureID the seats whose state changed
+// since they were last told. It is called after anything that takes or frees
+// seats, and does nothing if the departure has no feed.
+func (s *server) publishSeats(departureID string) {
+	feed, ok := s.seatFeeds[departureID]
+	if !ok {
+		return
+	}
+	dep, _ := s.catalog.departure(departureID)
+	seats, err := s.seatStatuses(dep)
+	if err != nil {
+		log.Printf("publish seat map of %s: %v", departureID, err)
+		return
+	}
+	var changed []*pb.SeatStatus
+	for _, seat := range seats {
+		if feed.states[seat.Seat] != seat.State {
+			feed.states[seat.Seat] = seat.State
+			changed = append(changed, seat)
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	feed.seq++
+	feed.events = append(feed.events, &pb.SeatMapEvent{
+		Seats:       changed,
+		ResumeToken: feed.resumeToken(feed.seq),
+		Time:        timestamppb.New(s.now()),
+	})
+	if len(feed.events) > seatFeedLength {
+		feed.events = feed.events[len(feed.events)-seatFeedLength:]
+	}
+	close(feed.changed)
+	feed.changed = make(chan struct{})
+}
+
+// seatStatuses returns the state of every seat on dep in allocation order.
+func (s *server) seatStatuses(dep *departure) ([]*pb.SeatStatus, error) {
+	seats, err := s.seatMap(dep)
+	if err != nil {
+		return nil, err
+	}
+	var statuses []*pb.SeatStatus
+	for _, l := range dep.Train.Sections {
+		for _, seat := range l.seatIDs() {
+			state := pb.SeatState_SEAT_STATE_FREE
+			if occupant, taken := seats.occupied[seat]; taken {
+				state = pb.SeatState_SEAT_STATE_BOOKED
+				if _, held := s.holds[occupant]; held {
+					state = pb.SeatState_SEAT_STATE_HELD
+				} else if _, paying := s.pending[occupant]; paying {
+					state = pb.SeatState_SEAT_STATE_HELD
+				}
+			}
+			statuses = append(statuses, &pb.SeatStatus{Seat: seat, Section: l.Name, State: state})
+		}
+	}
+	return statuses, nil
+}
+
+// resumeToken returns the token that resumes a watch of the feed's departure
+// after event seq. It names the feed that issued it, which is lost when the
+// server stops or the feed is dropped.
+func (f *seatFeed) resumeToken(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s:%d", f.epoch, f.departureID, seq)))
+}
+
+// parseResumeToken returns the event a resume token follows. resumable is
+// false if the token was issued by an earlier feed of the departure, in this
+// server process or an earlier one.
+func (f *seatFeed) parseResumeToken(token string) (seq int64, resumable bool, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, false, errInvalidResumeToken
+	}
+	parts := strings.Split(string(data), ":")
+	if len(parts) != 3 {
+		return 0, false, errInvalidResumeToken
+	}
+	if parts[1] != f.departureID {
+		return 0, false, fmt.Errorf("resume token is for departure %s", parts[1])
+	}
+	seq, err = strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || seq < 0 {
+		return 0, false, errInvalidResumeToken
+	}
+	return seq, parts[0] == f.epoch, nil
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// seatMapStream collects the events sent by WatchSeatMap.
+type seatMapStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pb.SeatMapEvent
+}
+
+func (w *seatMapStream) Context() context.Context { return w.ctx }
+
+func (w *seatMapStream) Send(event *pb.SeatMapEvent) error {
+	w.sent <- event
+	return nil
+}
+
+// watchSeatMap starts watching testDepartureID. Cancelling the returned
+// function ends the watch.
+func watchSeatMap(s *server, resumeToken string) (*seatMapStream, context.CancelFunc, chan error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &seatMapStream{ctx: ctx, sent: make(chan *pb.SeatMapEvent, 10)}
+	done := make(chan error, 1)
+	go func() {
+		done <- s.WatchSeatMap(&pb.WatchSeatMapRequest{DepartureId: testDepartureID, ResumeToken: resumeToken}, stream)
+	}()
+	return stream, cancel, done
+}
+
+// seatStates renders the seats of an event, e.g. "A1 held".
+func seatStates(event *pb.SeatMapEvent) []string {
+	var states []string
+	for _, seat := range event.Seats {
+		states = append(states, seat.Seat+" "+strings.ToLower(strings.TrimPrefix(seat.State.String(), "SEAT_STATE_")))
+	}
+	return states
+}
+
+func TestWatchSeatMap(t *testing.T) {
+	s := newSmallTrainServer()
+	ctx := context.Background()
+	_, err := purchaseWithToken(s, "ann@example.com", "B1", "")
+	require.NoError(t, err)
+
+	stream, cancel, done := watchSeatMap(s, "")
+	snapshot := <-stream.sent
+	assert.True(t, snapshot.Snapshot)
+	assert.Equal(t, []string{"A1 free", "A2 free", "B1 booked", "B2 free"}, seatStates(snapshot))
+
+	// A purchase holds the seat while paying, then books it
+	resp, err := purchaseWithToken(s, "john.doe@example.com", "A1", "")
+	require.NoError(t, err)
+	held := <-stream.sent
+	assert.False(t, held.Snapshot)
+	assert.Equal(t, []string{"A1 held"}, seatStates(held))
+	assert.Equal(t, []string{"A1 booked"}, seatStates(<-stream.sent))
+
+	_, err = s.ModifySeat(ctx, &pb.ModifySeatRequest{ReceiptId: resp.ReceiptId, NewSeat: "B2"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A1 free", "B2 booked"}, seatStates(<-stream.sent))
+	_, err = s.RemoveUser(ctx, &pb.RemoveUserRequest{Email: "john.doe@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"B2 free"}, seatStates(<-stream.sent))
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+
+	// A watcher that reconnects picks up where it left off
+	stream, cancel, done = watchSeatMap(s, held.ResumeToken)
+	assert.Equal(t, []string{"A1 booked"}, seatStates(<-stream.sent))
+	assert.Equal(t, []string{"A1 free", "B2 booked"}, seatStates(<-stream.sent))
+	assert.Equal(t, []string{"B2 free"}, seatStates(<-stream.sent))
+	cancel()
+	<-done
+
+	// Tokens from before a restart fall back to a snapshot
+	restarted := newSmallTrainServer()
+	stream, cancel, done = watchSeatMap(restarted, held.ResumeToken)
+	assert.True(t, (<-stream.sent).Snapshot)
+	cancel()
+	<-done
+
+	err = s.WatchSeatMap(&pb.WatchSeatMapRequest{DepartureId: testDepartureID, ResumeToken: "garbage"}, &seatMapStream{ctx: ctx})
+	fields, _ := errorDetails(err)
+	assert.Equal(t, []string{"resume_token"}, fields)
+	err = s.WatchSeatMap(&pb.WatchSeatMapRequest{DepartureId: "ES9024-20300901", ResumeToken: held.ResumeToken}, &seatMapStream{ctx: ctx})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	err = s.WatchSeatMap(&pb.WatchSeatMapRequest{DepartureId: "XX0000-20300901"}, &seatMapStream{ctx: ctx})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestWatchSeatMapHolds(t *testing.T) {
+	s := newSmallTrainServer()
+	stream, cancel, done := watchSeatMap(s, "")
+	defer func() { cancel(); <-done }()
+	<-stream.sent
+
+	hold, err := s.HoldSeats(context.Background(), &pb.HoldSeatsRequest{DepartureId: testDepartureID, Seats: []string{"A2", "B1"}, Email: "jane.doe@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A2 held", "B1 held"}, seatStates(<-stream.sent))
+
+	// Holds that lapse free their seats once reaped
+	_, err = s.expireHolds(hold.Hold.ExpiresAt.AsTime())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A2 free", "B1 free"}, seatStates(<-stream.sent))
+}
+
+func TestSeatFeedDropped(t *testing.T) {
+	s := newSmallTrainServer()
+	s.seatFeedIdle = 0
+
+	// The feed lasts while anybody is watching
+	first, cancelFirst, firstDone := watchSeatMap(s, "")
+	<-first.sent
+	second, cancelSecond, secondDone := watchSeatMap(s, "")
+	<-second.sent
+	_, err := purchaseWithToken(s, "ann@example.com", "A1", "")
+	require.NoError(t, err)
+	held := <-first.sent
+	<-first.sent
+	cancelFirst()
+	<-firstDone
+	s.mu.Lock()
+	assert.Contains(t, s.seatFeeds, testDepartureID)
+	s.mu.Unlock()
+
+	// and is dropped with its last watcher, whose tokens then only get a
+	// snapshot of the new feed
+	cancelSecond()
+	<-secondDone
+	s.mu.Lock()
+	assert.Empty(t, s.seatFeeds)
+	s.mu.Unlock()
+	stream, cancel, done := watchSeatMap(s, held.ResumeToken)
+	snapshot := <-stream.sent
+	assert.True(t, snapshot.Snapshot)
+	assert.Equal(t, []string{"A1 booked", "A2 free", "B1 free", "B2 free"}, seatStates(snapshot))
+	cancel()
+	<-done
+	assert.Empty(t, s.seatFeeds)
+
+	// Within the idle window a watcher can still resume
+	s.seatFeedIdle = time.Hour
+	stream, cancel, done = watchSeatMap(s, "")
+	token := (<-stream.sent).ResumeToken
+	cancel()
+	<-done
+	_, err = purchaseWithToken(s, "bob@example.com", "A2", "")
+	require.NoError(t, err)
+	stream, cancel, done = watchSeatMap(s, token)
+	assert.Equal(t, []string{"A2 held"}, seatStates(<-stream.sent))
+	cancel()
+	<-done
+}
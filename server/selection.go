@@ -0,0 +1,363 @@
+package main
+
+import (
+	"io"
+	"log"
+	"time"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+// holdWarningShare is the share of holdTTL left on a session's hold when the
+// client is warned that it is about to expire.
+const holdWarningShare = 5 // a fifth
+
+// selectionSession is the state of one SeatSelectionSession. Only the
+// goroutine serving the session uses it; the hold it points to is shared
+// with the rest of the server and is only touched under the server lock.
+type selectionSession struct {
+	s      *server
+	dep    *departure
+	stream pb.TicketService_SeatSelectionSessionServer
+	// email is the booker the session's seats are held for.
+	email string
+	// hold keeps the seats picked so far, in the order they were picked. It
+	// is nil until the first pick, and again once the hold is gone.
+	hold *seatHold
+	// warned is set once the client has been told the hold is expiring. A
+	// pick renews the hold and clears it.
+	warned bool
+}
+
+func (s *server) SeatSelectionSession(stream pb.TicketService_SeatSelectionSessionServer) error {
+	first, err := stream.Recv()
+	if err == io.EOF {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	start := first.GetStart()
+	if start == nil {
+		return badRequest(fieldViolation("start", "the first message must start the session"))
+	}
+	var violations []*errdetails.BadRequest_FieldViolation
+	if start.DepartureId == "" {
+		violations = append(violations, fieldViolation("start.departure_id", "departure_id is required"))
+	}
+	if start.Email == "" {
+		violations = append(violations, fieldViolation("start.email", "email is required"))
+	}
+	if len(violations) > 0 {
+		return badRequest(violations...)
+	}
+	dep, ok := s.catalog.departure(start.DepartureId)
+	if !ok {
+		return errorInfo(codes.NotFound, reasonDepartureNotFound, map[string]string{"departure_id": start.DepartureId},
+			"departure %q not found", start.DepartureId)
+	}
+
+	sess := &selectionSession{s: s, dep: dep, stream: stream, email: start.Email}
+	defer sess.release()
+	s.mu.Lock()
+	feed, err := s.seatFeed(dep)
+	s.mu.Unlock()
+	if err != nil {
+		return storeError(err)
+	}
+	defer s.leaveSeatFeed(feed)
+
+	// Read the client's actions in the background, so that seat map changes
+	// and expiry warnings can be pushed while waiting for them
+	actions := make(chan *pb.SeatSelectionRequest)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case actions <- req:
+			case <-stream.Context().Done():
+				return
+			}
+		}
+	}()
+
+	after := int64(-1)
+	for {
+		s.mu.Lock()
+		events := s.seatEventsSince(dep, feed, &after)
+		changed := feed.changed
+		alarm, hasAlarm := sess.nextAlarm()
+		s.mu.Unlock()
+
+		for _, event := range events {
+			if err := sess.send(&pb.SeatSelectionEvent{Event: &pb.SeatSelectionEvent_SeatMap{SeatMap: event}}); err != nil {
+				return err
+			}
+		}
+
+		var timer *time.Timer
+		var ring <-chan time.Time
+		if hasAlarm {
+			timer = time.NewTimer(alarm)
+			ring = timer.C
+		}
+		done, err := func() (bool, error) {
+			if timer != nil {
+				defer timer.Stop()
+			}
+			select {
+			case <-stream.Context().Done():
+				return true, stream.Context().Err()
+			case err := <-recvErr:
+				if err == io.EOF {
+					return true, nil
+				}
+				return true, err
+			case <-changed:
+				return false, nil
+			case <-ring:
+				return false, sess.checkHold()
+			case req := <-actions:
+				return sess.handle(req)
+			}
+		}()
+		if done || err != nil {
+			return err
+		}
+	}
+}
+
+// handle carries out an action from the client. It reports whether the
+// session is over.
+func (sess *selectionSession) handle(req *pb.SeatSelectionRequest) (bool, error) {
+	switch action := req.Action.(type) {
+	case *pb.SeatSelectionRequest_Pick:
+		return false, sess.pick(action.Pick)
+	case *pb.SeatSelectionRequest_Unpick:
+		return false, sess.unpick(action.Unpick)
+	case *pb.SeatSelectionRequest_Confirm:
+		return sess.confirm(action.Confirm)
+	case *pb.SeatSelectionRequest_Start:
+		return true, badRequest(fieldViolation("start", "the session has already started"))
+	}
+	return true, badRequest(fieldViolation("action", "an action is required"))
+}
+
+// pick adds seat to the session's hold, starting one if needed, and renews
+// the hold. A seat that does not exist or is taken is reported as a conflict.
+func (sess *selectionSession) pick(seat string) error {
+	s := sess.s
+	s.mu.Lock()
+	if expired := sess.expireLocked(); expired != nil {
+		s.mu.Unlock()
+		if err := sess.send(&pb.SeatSelectionEvent{Event: &pb.SeatSelectionEvent_Expired{Expired: expired}}); err != nil {
+			return err
+		}
+		s.mu.Lock()
+	}
+	hold := sess.hold
+	if hold != nil && contains(hold.seats, seat) {
+		current := hold.toProto()
+		s.mu.Unlock()
+		return sess.send(&pb.SeatSelectionEvent{Event: &pb.SeatSelectionEvent_Hold{Hold: current}})
+	}
+
+	seats, err := s.seatMap(sess.dep)
+	if err != nil {
+		s.mu.Unlock()
+		return storeError(err)
+	}
+	if _, err = seats.claim(seat, ""); err == nil && hold != nil && hold.receiptID != "" {
+		err = errorInfo(codes.FailedPrecondition, reasonHoldInUse, map[string]string{"hold_id": hold.id, "receipt_id": hold.receiptID},
+			"hold %q is being purchased as %s", hold.id, hold.receiptID)
+	}
+	if err != nil {
+		s.mu.Unlock()
+		conflict := bulkPurchaseError(err)
+		return sess.send(&pb.SeatSelectionEvent{Event: &pb.SeatSelectionEvent_Conflict{Conflict: &pb.SeatConflict{
+			Seat:    seat,
+			Reason:  conflict.Reason,
+			Message: conflict.Message,
+		}}})
+	}
+
+	if hold == nil {
+		sess.hold = s.newHold(sess.dep.ID, []string{seat}, sess.email)
+	} else {
+		// Copy the seats, which toProto hands out without the lock
+		hold.seats = append(append([]string(nil), hold.seats...), seat)
+		hold.expiresAt = s.now().Add(s.holdTTL)
+	}
+	sess.warned = false
+	s.publishSeats(sess.dep.ID)
+	current := sess.hold.toProto()
+	s.mu.Unlock()
+	return sess.send(&pb.SeatSelectionEvent{Event: &pb.SeatSelectionEvent_Hold{Hold: current}})
+}
+
+// unpick releases seat from the session's hold, dropping the hold once it is
+// empty. Unpicking a seat that is not held just reports the hold.
+func (sess *selectionSession) unpick(seat string) error {
+	s := sess.s
+	s.mu.Lock()
+	hold := sess.hold
+	if hold == nil || !contains(hold.seats, seat) || hold.receiptID != "" {
+		current := &pb.Hold{DepartureId: sess.dep.ID}
+		if hold != nil {
+			current = hold.toProto()
+		}
+		s.mu.Unlock()
+		return sess.send(&pb.SeatSelectionEvent{Event: &pb.SeatSelectionEvent_Hold{Hold: current}})
+	}
+
+	var kept []string
+	for _, held := range hold.seats {
+		if held != seat {
+			kept = append(kept, held)
+		}
+	}
+	hold.seats = kept
+	if len(kept) == 0 {
+		s.dropHold(hold, pb.WaitlistStatus_WAITLIST_STATUS_LEFT)
+		sess.hold = nil
+	}
+	if err := s.promoteWaitlist(sess.dep.ID); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	s.publishSeats(sess.dep.ID)
+	current := hold.toProto()
+	s.mu.Unlock()
+	return sess.send(&pb.SeatSelectionEvent{Event: &pb.SeatSelectionEvent_Hold{Hold: current}})
+}
+
+// confirm buys the held seats. It reports whether the session is over, which
+// it is once the purchase succeeds; a failed purchase keeps the hold so the
+// client can try again.
+func (sess *selectionSession) confirm(c *pb.ConfirmSelection) (bool, error) {
+	s := sess.s
+	s.mu.Lock()
+	var holdID string
+	if sess.hold != nil {
+		holdID = sess.hold.id
+	}
+	s.mu.Unlock()
+
+	var resp *pb.PurchaseResponse
+	err := errorInfo(codes.FailedPrecondition, reasonHoldNotFound, nil, "no seats are held, pick some first")
+	if holdID != "" {
+		resp, err = s.PurchaseTicket(sess.stream.Context(), &pb.PurchaseRequest{
+			From:         c.From,
+			To:           c.To,
+			User:         c.User,
+			Passengers:   c.Passengers,
+			DepartureId:  sess.dep.ID,
+			CurrencyCode: c.CurrencyCode,
+			PaymentToken: c.PaymentToken,
+			HoldId:       holdID,
+		})
+	}
+	if err != nil {
+		return false, sess.send(&pb.SeatSelectionEvent{Event: &pb.SeatSelectionEvent_PurchaseFailed{PurchaseFailed: bulkPurchaseError(err)}})
+	}
+
+	// Buying the seats used up the hold
+	s.mu.Lock()
+	sess.hold = nil
+	s.mu.Unlock()
+	return true, sess.send(&pb.SeatSelectionEvent{Event: &pb.SeatSelectionEvent_Confirmed{Confirmed: resp}})
+}
+
+// nextAlarm returns how long until the session's hold is due a warning or
+// expires. The server lock must be held.
+func (sess *selectionSession) nextAlarm() (time.Duration, bool) {
+	if sess.hold == nil || sess.hold.receiptID != "" {
+		return 0, false
+	}
+	at := sess.hold.expiresAt
+	if !sess.warned {
+		at = at.Add(-sess.s.holdTTL / holdWarningShare)
+	}
+	return at.Sub(sess.s.now()), true
+}
+
+// checkHold warns the client that its hold is about to expire, or tells it
+// the hold has expired.
+func (sess *selectionSession) checkHold() error {
+	s := sess.s
+	s.mu.Lock()
+	if expired := sess.expireLocked(); expired != nil {
+		s.mu.Unlock()
+		return sess.send(&pb.SeatSelectionEvent{Event: &pb.SeatSelectionEvent_Expired{Expired: expired}})
+	}
+	hold := sess.hold
+	if hold == nil || sess.warned || s.now().Before(hold.expiresAt.Add(-s.holdTTL/holdWarningShare)) {
+		s.mu.Unlock()
+		return nil
+	}
+	sess.warned = true
+	expiring := hold.toProto()
+	s.mu.Unlock()
+	return sess.send(&pb.SeatSelectionEvent{Event: &pb.SeatSelectionEvent_Expiring{Expiring: expiring}})
+}
+
+// expireLocked forgets the session's hold if it has expired, releasing its
+// seats unless the reaper already has, and returns it. It returns nil if the
+// hold is still live. The server lock must be held.
+func (sess *selectionSession) expireLocked() *pb.Hold {
+	s := sess.s
+	hold := sess.hold
+	if hold == nil || hold.receiptID != "" {
+		return nil
+	}
+	if s.holds[hold.id] == hold {
+		if !hold.expired(s.now()) {
+			return nil
+		}
+		s.dropHold(hold, pb.WaitlistStatus_WAITLIST_STATUS_EXPIRED)
+		if err := s.promoteWaitlist(sess.dep.ID); err != nil {
+			log.Printf("promote waitlist of %s: %v", sess.dep.ID, err)
+		}
+		s.publishSeats(sess.dep.ID)
+	}
+	sess.hold = nil
+	return hold.toProto()
+}
+
+// release gives up the seats still held when the session ends.
+func (sess *selectionSession) release() {
+	s := sess.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hold := sess.hold
+	if hold == nil || s.holds[hold.id] != hold || hold.receiptID != "" {
+		return
+	}
+	s.dropHold(hold, pb.WaitlistStatus_WAITLIST_STATUS_LEFT)
+	if err := s.promoteWaitlist(sess.dep.ID); err != nil {
+		log.Printf("promote waitlist of %s: %v", sess.dep.ID, err)
+	}
+	s.publishSeats(sess.dep.ID)
+}
+
+func (sess *selectionSession) send(event *pb.SeatSelectionEvent) error {
+	return sess.stream.Send(event)
+}
+
+// contains reports whether seats includes seat.
+func contains(seats []string, seat string) bool {
+	for _, s := range seats {
+		if s == seat {
+			return true
+		}
+	}
+	return false
+}
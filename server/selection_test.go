@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// selectionStream plays the client of a SeatSelectionSession: actions are
+// queued on recv, which is closed to end the session.
+type selectionStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	recv chan *pb.SeatSelectionRequest
+	sent chan *pb.SeatSelectionEvent
+}
+
+func (w *selectionStream) Context() context.Context { return w.ctx }
+
+func (w *selectionStream) Send(event *pb.SeatSelectionEvent) error {
+	w.sent <- event
+	return nil
+}
+
+func (w *selectionStream) Recv() (*pb.SeatSelectionRequest, error) {
+	select {
+	case req, ok := <-w.recv:
+		if !ok {
+			return nil, io.EOF
+		}
+		return req, nil
+	case <-w.ctx.Done():
+		return nil, w.ctx.Err()
+	}
+}
+
+// selectSeats starts a session for email on testDepartureID and skips past
+// its opening snapshot.
+func selectSeats(t *testing.T, s *server, email string) (*selectionStream, chan error) {
+	stream := &selectionStream{
+		ctx:  context.Background(),
+		recv: make(chan *pb.SeatSelectionRequest, 10),
+		sent: make(chan *pb.SeatSelectionEvent, 10),
+	}
+	stream.recv <- &pb.SeatSelectionRequest{Action: &pb.SeatSelectionRequest_Start{Start: &pb.StartSelection{DepartureId: testDepartureID, Email: email}}}
+	done := make(chan error, 1)
+	go func() { done <- s.SeatSelectionSession(stream) }()
+	require.True(t, (<-stream.sent).GetSeatMap().GetSnapshot())
+	return stream, done
+}
+
+func pick(seat string) *pb.SeatSelectionRequest {
+	return &pb.SeatSelectionRequest{Action: &pb.SeatSelectionRequest_Pick{Pick: seat}}
+}
+
+func unpick(seat string) *pb.SeatSelectionRequest {
+	return &pb.SeatSelectionRequest{Action: &pb.SeatSelectionRequest_Unpick{Unpick: seat}}
+}
+
+// nextEvent returns the next event other than a seat map change.
+func nextEvent(stream *selectionStream) *pb.SeatSelectionEvent {
+	for {
+		if event := <-stream.sent; event.GetSeatMap() == nil {
+			return event
+		}
+	}
+}
+
+func TestSeatSelectionSession(t *testing.T) {
+	s := newSmallTrainServer()
+	stream, done := selectSeats(t, s, "john.doe@example.com")
+
+	// Picks grow the hold, and the seat map shows them held
+	stream.recv <- pick("A1")
+	assert.Equal(t, []string{"A1"}, nextEvent(stream).GetHold().Seats)
+	assert.Equal(t, []string{"A1 held"}, seatStates((<-stream.sent).GetSeatMap()))
+	stream.recv <- pick("B1")
+	assert.Equal(t, []string{"A1", "B1"}, nextEvent(stream).GetHold().Seats)
+
+	// Seats held by someone else or unknown are conflicts, not errors
+	other, otherDone := selectSeats(t, s, "jane.doe@example.com")
+	other.recv <- pick("A1")
+	conflict := nextEvent(other).GetConflict()
+	require.NotNil(t, conflict)
+	assert.Equal(t, "A1", conflict.Seat)
+	assert.Equal(t, reasonSeatTaken, conflict.Reason)
+	other.recv <- pick("Z9")
+	assert.Equal(t, reasonSeatNotFound, nextEvent(other).GetConflict().Reason)
+
+	// Unpicking frees the seat for the other session
+	stream.recv <- unpick("A1")
+	assert.Equal(t, []string{"B1"}, nextEvent(stream).GetHold().Seats)
+	other.recv <- pick("A1")
+	assert.Equal(t, []string{"A1"}, nextEvent(other).GetHold().Seats)
+
+	// Only the session's booker can buy its seats. A failed purchase keeps
+	// the hold; a good one ends the session
+	confirm := &pb.ConfirmSelection{From: "London", To: "Paris", User: &pb.User{Email: "jane.doe@example.com"}}
+	stream.recv <- &pb.SeatSelectionRequest{Action: &pb.SeatSelectionRequest_Confirm{Confirm: confirm}}
+	failed := nextEvent(stream).GetPurchaseFailed()
+	require.NotNil(t, failed)
+	assert.Equal(t, reasonNotOwner, failed.Reason)
+	confirm = &pb.ConfirmSelection{From: "London", To: "Paris", User: &pb.User{Email: "john.doe@example.com"}, PaymentToken: fakeTokenDecline}
+	stream.recv <- &pb.SeatSelectionRequest{Action: &pb.SeatSelectionRequest_Confirm{Confirm: confirm}}
+	failed = nextEvent(stream).GetPurchaseFailed()
+	require.NotNil(t, failed)
+	assert.Equal(t, reasonPaymentDeclined, failed.Reason)
+	confirm.PaymentToken = ""
+	stream.recv <- &pb.SeatSelectionRequest{Action: &pb.SeatSelectionRequest_Confirm{Confirm: confirm}}
+	confirmed := nextEvent(stream).GetConfirmed()
+	require.NotNil(t, confirmed)
+	assert.NoError(t, <-done)
+	receipt, err := s.GetReceipt(context.Background(), &pb.ReceiptRequest{ReceiptId: confirmed.ReceiptId})
+	require.NoError(t, err)
+	assert.Equal(t, "B1", receipt.Seat)
+
+	// Leaving gives up whatever is still held
+	close(other.recv)
+	assert.NoError(t, <-otherDone)
+	assert.Empty(t, s.holds)
+	_, err = purchaseWithToken(s, "jane.doe@example.com", "A1", "")
+	assert.NoError(t, err)
+}
+
+func TestSeatSelectionSessionExpiry(t *testing.T) {
+	s := newSmallTrainServer()
+	s.holdTTL = 100 * time.Millisecond
+	stream, done := selectSeats(t, s, "john.doe@example.com")
+
+	stream.recv <- pick("A2")
+	hold := nextEvent(stream).GetHold()
+	expiring := nextEvent(stream).GetExpiring()
+	require.NotNil(t, expiring)
+	assert.Equal(t, hold.HoldId, expiring.HoldId)
+	expired := nextEvent(stream).GetExpired()
+	require.NotNil(t, expired)
+	assert.Equal(t, []string{"A2"}, expired.Seats)
+	assert.Empty(t, s.holds)
+
+	// Picking again starts a new hold
+	stream.recv <- pick("A2")
+	assert.NotEqual(t, hold.HoldId, nextEvent(stream).GetHold().HoldId)
+	close(stream.recv)
+	assert.NoError(t, <-done)
+}
+
+func TestSeatSelectionSessionStart(t *testing.T) {
+	s := newSmallTrainServer()
+	start := func(first *pb.SeatSelectionRequest) error {
+		stream := &selectionStream{ctx: context.Background(), recv: make(chan *pb.SeatSelectionRequest, 1), sent: make(chan *pb.SeatSelectionEvent, 10)}
+		stream.recv <- first
+		return s.SeatSelectionSession(stream)
+	}
+
+	fields, _ := errorDetails(start(pick("A1")))
+	assert.Equal(t, []string{"start"}, fields)
+	fields, _ = errorDetails(start(&pb.SeatSelectionRequest{Action: &pb.SeatSelectionRequest_Start{Start: &pb.StartSelection{DepartureId: testDepartureID}}}))
+	assert.Equal(t, []string{"start.email"}, fields)
+	err := start(&pb.SeatSelectionRequest{Action: &pb.SeatSelectionRequest_Start{Start: &pb.StartSelection{DepartureId: "XX0000-20300901", Email: "john.doe@example.com"}}})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
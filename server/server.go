@@ -2,190 +2,1018 @@ package main
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
-	"math/rand"
 	"net"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/Aravinthvvs/gRPC/boardingpass"
 	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 )
 
 type server struct {
 	pb.UnimplementedTicketServiceServer
-	mu          sync.Mutex
-	receipts    map[string]*pb.ReceiptResponse
-	userSeats   map[string]string
-	sectionA    map[string]string
-	sectionB    map[string]string
-	seatCounter int
+	mu        sync.Mutex
+	store     Store
+	catalog   *catalog
+	allocator SectionAllocator
+	ids       IDGenerator
+	fares     *fareRules
+	now       func() time.Time
+
+	cancellation *cancellationPolicy
+	// policy decides who may call each method once callers are
+	// authenticated.
+	policy *accessPolicy
+
+	payments       PaymentProvider
+	paymentTimeout time.Duration
+	// pending holds bookings whose seats are reserved while payment is
+	// taken, keyed by receipt ID. Neither they nor their seats are stored
+	// until payment is captured.
+	pending map[string]*pb.ReceiptResponse
+
+	holds   map[string]*seatHold
+	holdTTL time.Duration
+
+	// waitlist holds the entries still waiting or promoted, in join order.
+	waitlist          []*waitlistEntry
+	nextWaitlistEntry int
+
+	// passKey signs boarding passes.
+	passKey ed25519.PrivateKey
+
+	// seatFeeds hold the seat map changes of watched departures, keyed by
+	// departure ID. feedEpoch tells this process's resume tokens apart from
+	// those of earlier ones, and nextSeatFeed numbers the feeds it starts.
+	// Feeds nobody watches are dropped after seatFeedIdle.
+	seatFeeds    map[string]*seatFeed
+	feedEpoch    string
+	nextSeatFeed int
+	seatFeedIdle time.Duration
 }
 
-func newServer() *server {
+func newServer(store Store) *server {
+	// Passes signed with a throwaway key only last as long as the process;
+	// main loads a persistent one. Reading crypto/rand does not fail.
+	_, passKey, _ := ed25519.GenerateKey(rand.Reader)
 	return &server{
-		receipts:    make(map[string]*pb.ReceiptResponse),
-		userSeats:   make(map[string]string),
-		sectionA:    make(map[string]string),
-		sectionB:    make(map[string]string),
-		seatCounter: 0, // Initialize the seat counter
+		store:          store,
+		catalog:        defaultCatalog(),
+		allocator:      preferenceAllocator{fallback: balancedAllocator{}},
+		ids:            pnrIDs{},
+		fares:          defaultFareRules(),
+		now:            time.Now,
+		cancellation:   defaultCancellationPolicy(),
+		policy:         defaultAccessPolicy(),
+		payments:       newFakePaymentProvider(),
+		paymentTimeout: 10 * time.Second,
+		pending:        make(map[string]*pb.ReceiptResponse),
+		holds:          make(map[string]*seatHold),
+		holdTTL:        5 * time.Minute,
+		passKey:        passKey,
+		seatFeeds:      make(map[string]*seatFeed),
+		feedEpoch:      strconv.FormatInt(time.Now().UnixNano(), 36),
+		seatFeedIdle:   seatFeedIdle,
 	}
 }
 
+// PurchaseTicket reserves seats, takes payment and only then issues the
+// receipt. The server lock is not held while the payment provider is called,
+// so a slow gateway does not stall other bookings; the reserved seats stay
+// taken until the payment succeeds or fails.
 func (s *server) PurchaseTicket(ctx context.Context, req *pb.PurchaseRequest) (*pb.PurchaseResponse, error) {
+	receipt, err := s.reserve(req)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentID, err := s.pay(ctx, receipt, req.PaymentToken)
+	if err != nil {
+		if releaseErr := s.releaseReservation(receipt); releaseErr != nil {
+			return nil, storeError(releaseErr)
+		}
+		return nil, err
+	}
+
+	receipt.PaymentId = paymentID
+	if err := s.confirm(receipt); err != nil {
+		return nil, storeError(err)
+	}
+	return &pb.PurchaseResponse{ReceiptId: receipt.ReceiptId}, nil
+}
+
+// reserve validates req, allocates and prices its seats and holds them for a
+// pending booking.
+func (s *server) reserve(req *pb.PurchaseRequest) (*pb.ReceiptResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	// Basic validation
 	if req.User == nil {
-		return nil, fmt.Errorf("user information is required")
+		return nil, badRequest(fieldViolation("user", "user information is required"))
+	}
+	if req.User.Email == "" {
+		return nil, badRequest(fieldViolation("user.email", "user email is required"))
 	}
 	if req.From == "" || req.To == "" {
-		return nil, fmt.Errorf("from and to fields are required")
+		return nil, badRequest(fieldViolation("from", "from and to fields are required"), fieldViolation("to", "from and to fields are required"))
+	}
+	from, to, err := s.stations(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+	if req.DepartureId == "" {
+		return nil, badRequest(fieldViolation("departure_id", "departure_id is required"))
+	}
+	dep, ok := s.catalog.departure(req.DepartureId)
+	if !ok {
+		return nil, errorInfo(codes.NotFound, reasonDepartureNotFound, map[string]string{"departure_id": req.DepartureId},
+			"departure %q not found", req.DepartureId)
+	}
+	board, alight, ok := dep.segment(from, to)
+	if !ok {
+		return nil, badRequest(fieldViolation("departure_id", fmt.Sprintf("departure %q does not run from %s to %s", dep.ID, from.Name, to.Name)))
+	}
+	if leaves := dep.stopTime(board); !s.now().Before(leaves) {
+		return nil, errorInfo(codes.FailedPrecondition, reasonAlreadyDeparted, map[string]string{"departure_id": dep.ID},
+			"departure %s left %s at %s", dep.ID, from.Name, leaves.UTC().Format(time.RFC3339))
 	}
 
-	// Generate a receipt ID
-	receiptID := fmt.Sprintf("rec-%d", len(s.receipts)+1)
+	currency, rate, ok := s.fares.currency(req.CurrencyCode)
+	if !ok {
+		return nil, badRequest(fieldViolation("currency_code", fmt.Sprintf("fares are not offered in %q", req.CurrencyCode)))
+	}
 
-	// Generate a unique seat number
-	s.seatCounter++
-	seat := fmt.Sprintf("Seat-%d", s.seatCounter)
+	// Work out who is travelling
+	party := req.Passengers
+	if len(party) == 0 {
+		party = []*pb.User{req.User}
+	}
+	if err := validateParty(party); err != nil {
+		return nil, err
+	}
+	if req.PreferredSeat != "" && len(party) > 1 {
+		return nil, badRequest(fieldViolation("preferred_seat", "preferred_seat can only be used for a single passenger"))
+	}
+	var hold *seatHold
+	if req.HoldId != "" {
+		if hold, err = s.hold(req.HoldId); err != nil {
+			return nil, err
+		}
+		if err := hold.checkOwner(req.User.Email); err != nil {
+			return nil, err
+		}
+		var violations []*errdetails.BadRequest_FieldViolation
+		if hold.departureID != dep.ID {
+			violations = append(violations, fieldViolation("hold_id", fmt.Sprintf("hold %s is on departure %s", hold.id, hold.departureID)))
+		}
+		if len(hold.seats) != len(party) {
+			violations = append(violations, fieldViolation("hold_id", fmt.Sprintf("hold %s covers %d seats for %d passengers", hold.id, len(hold.seats), len(party))))
+		}
+		if req.PreferredSeat != "" {
+			violations = append(violations, fieldViolation("preferred_seat", "preferred_seat cannot be combined with hold_id"))
+		}
+		if len(violations) > 0 {
+			return nil, badRequest(violations...)
+		}
+	}
 
-	// Store receipt and user seat allocation
-	s.receipts[receiptID] = &pb.ReceiptResponse{
-		From:      req.From,
-		To:        req.To,
-		User:      req.User,
-		PricePaid: 20,
-		Seat:      seat,
+	// A passenger can only occupy one seat on a departure
+	for _, passenger := range party {
+		if err := s.checkNotBooked(passenger.Email, dep.ID); err != nil {
+			return nil, err
+		}
 	}
-	s.userSeats[req.User.Email] = seat
 
-	// Define sections
-	sections := []string{"SectionA", "SectionB"}
+	// Load the departure's seat inventory
+	seats, err := s.seatMap(dep)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	if req.PreferredSection != "" {
+		if _, ok := seats.section(req.PreferredSection); !ok {
+			return nil, badRequest(fieldViolation("preferred_section", fmt.Sprintf("train %s has no section %q", dep.Train.ID, req.PreferredSection)))
+		}
+	}
+	if hold == nil && seats.soldOut() {
+		return nil, errorInfo(codes.ResourceExhausted, reasonSoldOut, map[string]string{"departure_id": dep.ID},
+			"departure %s is sold out", dep.ID)
+	}
+
+	var assigned []string
+	if hold != nil {
+		// Seats held for this purchase
+		assigned = hold.seats
+	} else if seat := req.PreferredSeat; seat != "" {
+		// Seat chosen by the passenger
+		section, err := seats.claim(seat, "")
+		if err != nil {
+			return nil, err
+		}
+		if req.PreferredSection != "" && req.PreferredSection != section.Name {
+			return nil, badRequest(fieldViolation("preferred_seat", fmt.Sprintf("seat %s is in %s, not %s", seat, section.Name, req.PreferredSection)))
+		}
+		assigned = []string{seat}
+	} else {
+		// Select a section that still has room and seat the party together
+		section := s.allocator.Allocate(req, seats, seats.openSections())
+		if assigned, err = seats.allocateParty(section, len(party)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Generate a receipt ID, only once the booking can go ahead
+	receiptID, err := s.newReceiptID()
+	if err != nil {
+		return nil, storeError(err)
+	}
 
-	// Seed random number generator
-	rand.Seed(time.Now().UnixNano())
+	// Build one seat line per passenger
+	receipt := &pb.ReceiptResponse{
+		From:        from.Name,
+		To:          to.Name,
+		User:        req.User,
+		DepartureId: dep.ID,
+		ReceiptId:   receiptID,
+		Status:      pb.BookingStatus_BOOKING_STATUS_CONFIRMED,
+	}
+	for i, passenger := range party {
+		section, _ := seats.sectionOf(assigned[i])
+		receipt.SeatLines = append(receipt.SeatLines, &pb.SeatLine{
+			Passenger: passenger,
+			Seat:      assigned[i],
+			Section:   section.Name,
+		})
+	}
 
-	// Randomly select a section
-	section := sections[rand.Intn(len(sections))]
+	// Price the journey
+	receipt.Fare = s.fares.fare(receipt.SeatLines, alight.DistanceKm-board.DistanceKm, s.daysAhead(dep), currency, rate)
+	receipt.PricePaid = receipt.Fare.Total
 
-	if section == "SectionA" {
-		s.sectionA[req.User.Email] = seat
-	} else {
-		s.sectionB[req.User.Email] = seat
+	// Hold the seats until payment is taken. They are only stored once it
+	// is, so a crash mid-payment leaves no seats taken by unpaid bookings.
+	s.pending[receiptID] = receipt
+	if hold != nil {
+		hold.receiptID = receiptID
 	}
+	s.publishSeats(dep.ID)
 
-	return &pb.PurchaseResponse{ReceiptId: receiptID}, nil
+	return receipt, nil
 }
 
-func (s *server) GetReceipt(ctx context.Context, req *pb.ReceiptRequest) (*pb.ReceiptResponse, error) {
+// pay authorises and captures the price of receipt, voiding the authorisation
+// if it cannot be captured. It returns the provider's payment ID.
+func (s *server) pay(ctx context.Context, receipt *pb.ReceiptResponse, token string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.paymentTimeout)
+	defer cancel()
+
+	authorizationID, err := s.payments.Authorize(ctx, &paymentRequest{
+		ReceiptID: receipt.ReceiptId,
+		Amount:    receipt.PricePaid,
+		Token:     token,
+	})
+	if err != nil {
+		return "", paymentError(ctx, err, "authorize")
+	}
+	if err := s.payments.Capture(ctx, authorizationID); err != nil {
+		if voidErr := s.payments.Void(context.Background(), authorizationID); voidErr != nil {
+			log.Printf("void %s for %s: %v", authorizationID, receipt.ReceiptId, voidErr)
+		}
+		return "", paymentError(ctx, err, "capture")
+	}
+	return authorizationID, nil
+}
+
+// releaseReservation frees the seats held for a booking whose payment failed.
+// Seats taken from a hold go back to the hold until it expires; others are
+// offered to the waitlist.
+func (s *server) releaseReservation(receipt *pb.ReceiptResponse) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	receipt, exists := s.receipts[req.ReceiptId]
-	if !exists {
-		return nil, fmt.Errorf("receipt not found")
+	delete(s.pending, receipt.ReceiptId)
+	if hold := s.holdFor(receipt.ReceiptId); hold != nil {
+		hold.receiptID = ""
 	}
+	if err := s.promoteWaitlist(receipt.DepartureId); err != nil {
+		return err
+	}
+	s.publishSeats(receipt.DepartureId)
+	return nil
+}
 
-	return receipt, nil
+// confirm issues the receipt of a paid booking.
+func (s *server) confirm(receipt *pb.ReceiptResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.pending, receipt.ReceiptId)
+	if hold := s.holdFor(receipt.ReceiptId); hold != nil {
+		s.dropHold(hold, pb.WaitlistStatus_WAITLIST_STATUS_BOOKED)
+	}
+	if err := s.saveTicket(receipt); err != nil {
+		return err
+	}
+	s.publishSeats(receipt.DepartureId)
+	return nil
 }
 
+func (s *server) GetReceipt(ctx context.Context, req *pb.ReceiptRequest) (*pb.ReceiptResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.ReceiptId == "" {
+		return nil, badRequest(fieldViolation("receipt_id", "receipt_id is required"))
+	}
+	return s.receipt(req.ReceiptId)
+}
+
+// ViewUsersBySection returns the manifest of a section, across every
+// departure unless the request narrows it to one departure or route.
 func (s *server) ViewUsersBySection(ctx context.Context, req *pb.ViewUsersRequest) (*pb.ViewUsersResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	var userSeats map[string]string
-	if req.Section == "SectionA" {
-		userSeats = s.sectionA
-	} else if req.Section == "SectionB" {
-		userSeats = s.sectionB
-	} else {
-		return nil, fmt.Errorf("invalid section")
+	if req.DepartureId != "" {
+		if _, ok := s.catalog.departure(req.DepartureId); !ok {
+			return nil, errorInfo(codes.NotFound, reasonDepartureNotFound, map[string]string{"departure_id": req.DepartureId},
+				"departure %q not found", req.DepartureId)
+		}
+	}
+	if _, ok := s.catalog.routesByID[req.RouteId]; req.RouteId != "" && !ok {
+		return nil, badRequest(fieldViolation("route_id", fmt.Sprintf("unknown route %q", req.RouteId)))
+	}
+	userSeats, err := s.store.SectionSeats(req.Section)
+	if errors.Is(err, errNotFound) {
+		return nil, badRequest(fieldViolation("section", fmt.Sprintf("invalid section %q, expected SectionA or SectionB", req.Section)))
+	}
+	if err != nil {
+		return nil, storeError(err)
 	}
 
 	var userSeatList []*pb.UserSeat
-	for email, seat := range userSeats {
-		userSeatList = append(userSeatList, &pb.UserSeat{
-			User: &pb.User{
-				Email: email,
-			},
-			Seat: seat,
-		})
+	for receiptID, ids := range userSeats {
+		receipt, err := s.store.Receipt(receiptID)
+		if err != nil {
+			return nil, storeError(err)
+		}
+		if req.DepartureId != "" && receipt.DepartureId != req.DepartureId {
+			continue
+		}
+		var routeID string
+		if dep, ok := s.catalog.departure(receipt.DepartureId); ok {
+			routeID = dep.Route.ID
+		}
+		if req.RouteId != "" && routeID != req.RouteId {
+			continue
+		}
+		from, _ := s.catalog.station(receipt.From)
+		to, _ := s.catalog.station(receipt.To)
+		for _, line := range seatLines(receipt) {
+			for _, seat := range ids {
+				if line.Seat == seat {
+					userSeat := &pb.UserSeat{
+						User:        line.Passenger,
+						Seat:        seat,
+						ReceiptId:   receipt.ReceiptId,
+						DepartureId: receipt.DepartureId,
+						Section:     line.Section,
+						RouteId:     routeID,
+					}
+					if from != nil && to != nil {
+						userSeat.From = from.toProto()
+						userSeat.To = to.toProto()
+					}
+					userSeatList = append(userSeatList, userSeat)
+				}
+			}
+		}
+	}
+	page, next, total, err := listPage(req, userSeatList, manifestFields, manifestKey(req.OrderBy))
+	if err != nil {
+		return nil, err
 	}
+	return &pb.ViewUsersResponse{UserSeats: page, NextPageToken: next, TotalSize: total}, nil
+}
 
-	return &pb.ViewUsersResponse{UserSeats: userSeatList}, nil
+// manifestFields are the fields a manifest filter can test.
+var manifestFields = map[string]listField[*pb.UserSeat]{
+	"domain":    {values: func(us *pb.UserSeat) []string { return []string{emailDomain(us.User.GetEmail())} }},
+	"email":     {values: func(us *pb.UserSeat) []string { return []string{us.User.GetEmail()} }},
+	"name":      {values: func(us *pb.UserSeat) []string { return []string{us.User.GetFirstName() + " " + us.User.GetLastName()} }},
+	"seat":      {values: func(us *pb.UserSeat) []string { return []string{us.Seat} }, less: seatLess},
+	"section":   {values: func(us *pb.UserSeat) []string { return []string{us.Section} }},
+	"route":     {values: func(us *pb.UserSeat) []string { return []string{us.RouteId} }},
+	"departure": {values: func(us *pb.UserSeat) []string { return []string{us.DepartureId} }},
+	"receipt":   {values: func(us *pb.UserSeat) []string { return []string{us.ReceiptId} }},
+}
+
+// manifestKey returns the sort key of a manifest in the given order. A seat
+// on a departure is unique, so it ends every key.
+func manifestKey(order pb.ManifestOrder) func(*pb.UserSeat) []string {
+	if order == pb.ManifestOrder_MANIFEST_ORDER_NAME {
+		return func(us *pb.UserSeat) []string {
+			return []string{
+				strings.ToLower(us.User.GetLastName()), strings.ToLower(us.User.GetFirstName()), strings.ToLower(us.User.GetEmail()),
+				us.DepartureId, seatKey(us.Seat),
+			}
+		}
+	}
+	return func(us *pb.UserSeat) []string { return []string{us.DepartureId, seatKey(us.Seat)} }
 }
 
 func (s *server) RemoveUser(ctx context.Context, req *pb.RemoveUserRequest) (*pb.RemoveUserResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	_, exists := s.userSeats[req.Email]
-	if !exists {
-		return &pb.RemoveUserResponse{Success: false}, nil
+	if req.Email == "" {
+		return nil, badRequest(fieldViolation("email", "email is required"))
+	}
+	receipts, err := s.receiptsForEmail(req.Email)
+	if err != nil {
+		return nil, storeError(err)
 	}
 
-	// Remove from sections
-	delete(s.sectionA, req.Email)
-	delete(s.sectionB, req.Email)
-
-	delete(s.userSeats, req.Email)
-
-	// Remove receipt
-	for receiptID, receipt := range s.receipts {
-		if receipt.User.Email == req.Email {
-			delete(s.receipts, receiptID)
-			break
+	// Cancel every ticket the user booked, and the user's seat on the tickets
+	// of parties they travel with
+	removed := func(receipt *pb.ReceiptResponse) func(*pb.SeatLine) bool {
+		booker := receipt.User.GetEmail() == req.Email
+		return func(line *pb.SeatLine) bool { return booker || line.Passenger.GetEmail() == req.Email }
+	}
+	// Nobody is removed part way if a passenger it would cancel has boarded
+	for _, receipt := range receipts {
+		if err := notBoarded(receipt, removed(receipt)); err != nil {
+			return nil, err
+		}
+	}
+	var refunds []*pb.Refund
+	for _, receipt := range receipts {
+		// cancelSeats lets go of the lock while refunding, so the receipts
+		// after the first may have changed
+		receipt, err := s.store.Receipt(receipt.ReceiptId)
+		if err != nil {
+			return nil, storeError(err)
+		}
+		refund, err := s.cancelSeats(ctx, receipt, removed(receipt))
+		if err != nil {
+			return nil, err
 		}
+		if refund != nil {
+			refunds = append(refunds, refund)
+		}
+	}
+	if len(refunds) == 0 {
+		return nil, userNotFound(req.Email)
 	}
 
-	return &pb.RemoveUserResponse{Success: true}, nil
+	return &pb.RemoveUserResponse{Success: true, Refunds: refunds}, nil
 }
 
 func (s *server) ModifySeat(ctx context.Context, req *pb.ModifySeatRequest) (*pb.ModifySeatResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	_, exists := s.userSeats[req.Email]
-	if !exists {
-		return &pb.ModifySeatResponse{Success: false}, nil
+	var violations []*errdetails.BadRequest_FieldViolation
+	if req.ReceiptId == "" && req.Email == "" {
+		violations = append(violations, fieldViolation("receipt_id", "receipt_id is required"))
+	}
+	if req.NewSeat == "" {
+		violations = append(violations, fieldViolation("new_seat", "new_seat is required"))
+	}
+	if len(violations) > 0 {
+		return nil, badRequest(violations...)
+	}
+
+	receipt, err := s.resolveTicket(req.ReceiptId, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if err := bookingCancelled(receipt); err != nil {
+		return nil, err
+	}
+	line, err := seatLineFor(receipt, "current_seat", req.CurrentSeat)
+	if err != nil {
+		return nil, err
 	}
 
-	// Update seat in the corresponding section
-	section := "SectionA"
-	if _, ok := s.sectionB[req.Email]; ok {
-		section = "SectionB"
+	// Check the new seat exists on the ticket's departure and is free
+	dep, ok := s.catalog.departure(receipt.DepartureId)
+	if !ok {
+		return nil, errorInfo(codes.FailedPrecondition, reasonDepartureNotFound, map[string]string{"departure_id": receipt.DepartureId},
+			"departure %q is no longer in the catalog", receipt.DepartureId)
+	}
+	seats, err := s.seatMap(dep)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	section, err := seats.claim(req.NewSeat, receipt.ReceiptId)
+	if err != nil {
+		return nil, err
 	}
 
-	if section == "SectionA" {
-		s.sectionA[req.Email] = req.NewSeat
-	} else {
-		s.sectionB[req.Email] = req.NewSeat
+	// Move the passenger, switching section if the seat lives in the other one
+	oldSeat := line.Seat
+	line.Seat = req.NewSeat
+	line.Section = section.Name
+	if err := s.saveTicket(receipt, oldSeat); err != nil {
+		return nil, storeError(err)
+	}
+	if err := s.promoteWaitlist(dep.ID); err != nil {
+		return nil, err
 	}
+	s.publishSeats(dep.ID)
 
-	// Update receipt with new seat
-	for _, receipt := range s.receipts {
-		if receipt.User.Email == req.Email {
-			receipt.Seat = req.NewSeat
-			break
+	return &pb.ModifySeatResponse{Success: true}, nil
+}
+
+func (s *server) CancelTicket(ctx context.Context, req *pb.CancelTicketRequest) (*pb.CancelTicketResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.ReceiptId == "" {
+		return nil, badRequest(fieldViolation("receipt_id", "receipt_id is required"))
+	}
+	receipt, err := s.resolveTicket(req.ReceiptId, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := bookingCancelled(receipt); err != nil {
+		return nil, err
+	}
+	refund, err := s.cancelSeats(ctx, receipt, func(*pb.SeatLine) bool { return true })
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CancelTicketResponse{Success: true, Refund: refund}, nil
+}
+
+func (s *server) ListMyTickets(ctx context.Context, req *pb.ListMyTicketsRequest) (*pb.ListMyTicketsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if req.Email == "" {
+		return nil, badRequest(fieldViolation("email", "email is required"))
+	}
+	receipts, err := s.receiptsForEmail(req.Email)
+	if err != nil {
+		return nil, storeError(err)
+	}
+
+	// Soonest journey first
+	page, next, total, err := listPage(req, receipts, s.ticketFields(), func(r *pb.ReceiptResponse) []string {
+		return []string{s.departureTime(r.DepartureId).UTC().Format(time.RFC3339), r.ReceiptId}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListMyTicketsResponse{Receipts: page, NextPageToken: next, TotalSize: total}, nil
+}
+
+// ticketFields are the fields a ListMyTickets filter can test.
+func (s *server) ticketFields() map[string]listField[*pb.ReceiptResponse] {
+	// Lines match whether or not they were cancelled, so cancelled tickets
+	// can still be found by passenger or seat.
+	lineValues := func(value func(*pb.SeatLine) string) func(*pb.ReceiptResponse) []string {
+		return func(r *pb.ReceiptResponse) []string {
+			var values []string
+			for _, lines := range [][]*pb.SeatLine{seatLines(r), r.CancelledSeatLines} {
+				for _, line := range lines {
+					values = append(values, value(line))
+				}
+			}
+			return values
 		}
 	}
+	return map[string]listField[*pb.ReceiptResponse]{
+		"domain":  {values: lineValues(func(line *pb.SeatLine) string { return emailDomain(line.Passenger.GetEmail()) })},
+		"email":   {values: lineValues(func(line *pb.SeatLine) string { return line.Passenger.GetEmail() })},
+		"seat":    {values: lineValues(func(line *pb.SeatLine) string { return line.Seat }), less: seatLess},
+		"section": {values: lineValues(func(line *pb.SeatLine) string { return line.Section })},
+		"route": {values: func(r *pb.ReceiptResponse) []string {
+			if dep, ok := s.catalog.departure(r.DepartureId); ok {
+				return []string{dep.Route.ID}
+			}
+			return nil
+		}},
+		"departure": {values: func(r *pb.ReceiptResponse) []string { return []string{r.DepartureId} }},
+		"status": {values: func(r *pb.ReceiptResponse) []string {
+			if r.Status == pb.BookingStatus_BOOKING_STATUS_CANCELLED {
+				return []string{"cancelled"}
+			}
+			return []string{"confirmed"}
+		}},
+	}
+}
 
-	s.userSeats[req.Email] = req.NewSeat
+// userNotFound reports that email holds no ticket.
+func userNotFound(email string) error {
+	return errorInfo(codes.NotFound, reasonUserNotFound, map[string]string{"email": email},
+		"no ticket found for %s", email)
+}
 
-	return &pb.ModifySeatResponse{Success: true}, nil
+// stations resolves the from and to stations of a request.
+func (s *server) stations(fromKey, toKey string) (*station, *station, error) {
+	var violations []*errdetails.BadRequest_FieldViolation
+	from, ok := s.catalog.station(fromKey)
+	if !ok {
+		violations = append(violations, fieldViolation("from", fmt.Sprintf("unknown station %q", fromKey)))
+	}
+	to, ok := s.catalog.station(toKey)
+	if !ok {
+		violations = append(violations, fieldViolation("to", fmt.Sprintf("unknown station %q", toKey)))
+	}
+	if len(violations) > 0 {
+		return nil, nil, badRequest(violations...)
+	}
+	return from, to, nil
+}
+
+// receiptsForEmail returns every receipt booked for email.
+func (s *server) receiptsForEmail(email string) ([]*pb.ReceiptResponse, error) {
+	receiptIDs, err := s.store.ReceiptIDsForEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	var receipts []*pb.ReceiptResponse
+	for _, receiptID := range receiptIDs {
+		receipt, err := s.store.Receipt(receiptID)
+		if err != nil {
+			return nil, err
+		}
+		receipts = append(receipts, receipt)
+	}
+	return receipts, nil
+}
+
+// resolveTicket returns the ticket identified by receiptID or, when receiptID
+// is empty, the only ticket email holds that has not been cancelled.
+func (s *server) resolveTicket(receiptID, email string) (*pb.ReceiptResponse, error) {
+	if receiptID == "" {
+		all, err := s.receiptsForEmail(email)
+		if err != nil {
+			return nil, storeError(err)
+		}
+		var receipts []*pb.ReceiptResponse
+		for _, receipt := range all {
+			if receipt.Status != pb.BookingStatus_BOOKING_STATUS_CANCELLED {
+				receipts = append(receipts, receipt)
+			}
+		}
+		switch len(receipts) {
+		case 0:
+			return nil, userNotFound(email)
+		case 1:
+			return receipts[0], nil
+		}
+		return nil, badRequest(fieldViolation("receipt_id",
+			fmt.Sprintf("%s holds %d tickets, receipt_id is required", email, len(receipts))))
+	}
+
+	return s.receipt(receiptID)
+}
+
+// validateParty checks that every passenger has an email and that nobody is
+// listed twice.
+func validateParty(party []*pb.User) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	seen := make(map[string]bool)
+	for i, passenger := range party {
+		field := fmt.Sprintf("passengers[%d].email", i)
+		switch {
+		case passenger.GetEmail() == "":
+			violations = append(violations, fieldViolation(field, "passenger email is required"))
+		case seen[passenger.Email]:
+			violations = append(violations, fieldViolation(field, fmt.Sprintf("passenger %s is listed more than once", passenger.Email)))
+		}
+		if _, ok := pb.PassengerType_name[int32(passenger.GetPassengerType())]; !ok {
+			violations = append(violations, fieldViolation(fmt.Sprintf("passengers[%d].passenger_type", i),
+				fmt.Sprintf("unknown passenger type %d", passenger.GetPassengerType())))
+		}
+		seen[passenger.GetEmail()] = true
+	}
+	if len(violations) > 0 {
+		return badRequest(violations...)
+	}
+	return nil
+}
+
+// checkNotBooked fails with ALREADY_EXISTS if email already has a seat on
+// departureID.
+func (s *server) checkNotBooked(email, departureID string) error {
+	receipts, err := s.receiptsForEmail(email)
+	if err != nil {
+		return storeError(err)
+	}
+	for _, receipt := range s.pending {
+		receipts = append(receipts, receipt)
+	}
+	for _, receipt := range receipts {
+		if receipt.DepartureId != departureID {
+			continue
+		}
+		for _, line := range seatLines(receipt) {
+			if line.Passenger.GetEmail() == email {
+				return errorInfo(codes.AlreadyExists, reasonAlreadyBooked,
+					map[string]string{"email": email, "departure_id": departureID, "receipt_id": receipt.ReceiptId},
+					"%s already holds seat %s on departure %s", email, line.Seat, departureID)
+			}
+		}
+	}
+	return nil
+}
+
+// maxIDAttempts bounds how many IDs newReceiptID draws before giving up.
+const maxIDAttempts = 100
+
+// newReceiptID returns an ID not used by any stored or pending booking.
+func (s *server) newReceiptID() (string, error) {
+	for i := 0; i < maxIDAttempts; i++ {
+		receiptID, err := s.ids.NewID()
+		if err != nil {
+			return "", err
+		}
+		if _, pending := s.pending[receiptID]; pending {
+			continue
+		}
+		if _, err := s.store.Receipt(receiptID); errors.Is(err, errNotFound) {
+			return receiptID, nil
+		} else if err != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("no free receipt ID after %d attempts", maxIDAttempts)
+}
+
+// receipt returns the receipt receiptID. IDs are matched as typed and then in
+// their canonical form, so a code read out in lower case still finds its
+// booking, while one that fails its check character is reported as a typo.
+func (s *server) receipt(receiptID string) (*pb.ReceiptResponse, error) {
+	receipt, err := s.store.Receipt(receiptID)
+	if errors.Is(err, errNotFound) {
+		canonical, cerr := s.ids.Canonical(receiptID)
+		if errors.Is(cerr, errMistypedID) {
+			return nil, badRequest(fieldViolation("receipt_id", fmt.Sprintf("receipt_id %q looks mistyped: %v", receiptID, cerr)))
+		}
+		if cerr == nil && canonical != receiptID {
+			receipt, err = s.store.Receipt(canonical)
+		}
+	}
+	if errors.Is(err, errNotFound) {
+		return nil, errorInfo(codes.NotFound, reasonReceiptNotFound, map[string]string{"receipt_id": receiptID},
+			"receipt %q not found", receiptID)
+	}
+	if err != nil {
+		return nil, storeError(err)
+	}
+	return receipt, nil
+}
+
+// seatLines returns the seats held by receipt. Receipts written before party
+// bookings carry a single seat and no seat lines.
+func seatLines(receipt *pb.ReceiptResponse) []*pb.SeatLine {
+	if len(receipt.SeatLines) > 0 || receipt.Status != pb.BookingStatus_BOOKING_STATUS_UNSPECIFIED {
+		return receipt.SeatLines
+	}
+	receipt.SeatLines = []*pb.SeatLine{{Passenger: receipt.User, Seat: receipt.Seat, Section: receipt.Section}}
+	return receipt.SeatLines
+}
+
+// seatLineFor returns the line of receipt holding seat, which the request
+// passed in field. seat may be empty when the ticket holds a single seat.
+func seatLineFor(receipt *pb.ReceiptResponse, field, seat string) (*pb.SeatLine, error) {
+	lines := seatLines(receipt)
+	if seat == "" {
+		if len(lines) == 1 {
+			return lines[0], nil
+		}
+		return nil, badRequest(fieldViolation(field,
+			fmt.Sprintf("receipt %s holds %d seats, %s is required", receipt.ReceiptId, len(lines), field)))
+	}
+	for _, line := range lines {
+		if line.Seat == seat {
+			return line, nil
+		}
+	}
+	return nil, badRequest(fieldViolation(field, fmt.Sprintf("receipt %s does not hold seat %s", receipt.ReceiptId, seat)))
+}
+
+// saveTicket stores receipt, taking the seats of its seat lines and freeing
+// released, in one store call so that a failure cannot leave them half
+// written. The first seat line is mirrored into the receipt's seat and
+// section fields.
+func (s *server) saveTicket(receipt *pb.ReceiptResponse, released ...string) error {
+	if lines := seatLines(receipt); len(lines) > 0 {
+		receipt.Seat = lines[0].Seat
+		receipt.Section = lines[0].Section
+	}
+	return s.store.SaveTicket(receipt, released)
+}
+
+// daysAhead returns the number of whole days between today and dep, or 0 if
+// dep runs today or has already run.
+func (s *server) daysAhead(dep *departure) int {
+	now := s.now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if days := int(dep.Date.Sub(today).Hours() / 24); days > 0 {
+		return days
+	}
+	return 0
+}
+
+// departureTime returns when departureID leaves its first station, or the
+// zero time if it is not in the catalog.
+func (s *server) departureTime(departureID string) time.Time {
+	dep, ok := s.catalog.departure(departureID)
+	if !ok {
+		return time.Time{}
+	}
+	return dep.stopTime(dep.Route.Stops[0])
+}
+
+func (s *server) ListStations(ctx context.Context, req *pb.ListStationsRequest) (*pb.ListStationsResponse, error) {
+	var stations []*pb.Station
+	for _, st := range s.catalog.Stations {
+		stations = append(stations, st.toProto())
+	}
+	page, next, total, err := listPage(req, stations, stationFields, func(st *pb.Station) []string {
+		return []string{st.Name, st.Id}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListStationsResponse{Stations: page, NextPageToken: next, TotalSize: total}, nil
+}
+
+// stationFields are the fields a ListStations filter can test.
+var stationFields = map[string]listField[*pb.Station]{
+	"id":   {values: func(st *pb.Station) []string { return []string{st.Id} }},
+	"name": {values: func(st *pb.Station) []string { return []string{st.Name} }},
+}
+
+func (s *server) ListDepartures(ctx context.Context, req *pb.ListDeparturesRequest) (*pb.ListDeparturesResponse, error) {
+	from, to, err := s.stations(req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+	date, err := time.Parse(dateLayout, req.Date)
+	if err != nil {
+		return nil, badRequest(fieldViolation("date", fmt.Sprintf("invalid date %q, expected YYYY-MM-DD", req.Date)))
+	}
+
+	var departures []*pb.Departure
+	for _, dep := range s.catalog.departures(from, to, date) {
+		departures = append(departures, dep.toProto(from, to))
+	}
+	page, next, total, err := listPage(req, departures, departureFields, func(d *pb.Departure) []string {
+		return []string{d.DepartureTime.AsTime().UTC().Format(time.RFC3339), d.Id}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListDeparturesResponse{Departures: page, NextPageToken: next, TotalSize: total}, nil
+}
+
+// departureFields are the fields a ListDepartures filter can test.
+var departureFields = map[string]listField[*pb.Departure]{
+	"id":    {values: func(d *pb.Departure) []string { return []string{d.Id} }},
+	"train": {values: func(d *pb.Departure) []string { return []string{d.TrainId} }},
+	"route": {values: func(d *pb.Departure) []string { return []string{d.RouteId} }},
+}
+
+// openStore returns the Store selected by the -store flag.
+func openStore(kind, path string) (Store, error) {
+	switch kind {
+	case "memory":
+		return newMemoryStore(), nil
+	case "bolt":
+		return openBoltStore(path)
+	}
+	return nil, fmt.Errorf("unknown store %q", kind)
+}
+
+// serverCredentials returns the option that serves TLS with the certificate
+// and key given by -tls-cert and -tls-key, or nil if neither was given.
+func serverCredentials(certFile, keyFile string) (grpc.ServerOption, error) {
+	if certFile == "" && keyFile == "" {
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("-tls-cert and -tls-key must be given together")
+	}
+	creds, err := credentials.NewServerTLSFromFile(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return grpc.Creds(creds), nil
 }
 
 func main() {
+	storeKind := flag.String("store", "memory", "booking storage backend: memory or bolt")
+	dbPath := flag.String("db", "tickets.db", "database file used by the bolt store")
+	catalogPath := flag.String("catalog", "", "JSON file with stations, routes and trains (default: built-in network)")
+	allocatorName := flag.String("allocator", "balanced", "section allocation strategy for passengers without a preferred section: balanced, fill-first or random")
+	cancellationPath := flag.String("cancellation", "", "JSON file with the cancellation refund policy (default: full refund up to 48h before departure, 50% after)")
+	holdTTL := flag.Duration("hold-ttl", 5*time.Minute, "how long HoldSeats keeps seats free")
+	paymentsName := flag.String("payments", "fake", "payment provider: fake")
+	paymentTimeout := flag.Duration("payment-timeout", 10*time.Second, "how long to wait for the payment provider")
+	faresPath := flag.String("fares", "", "JSON file with fare rules (default: built-in fares)")
+	boardingKeyPath := flag.String("boarding-key", "boarding.key", "file holding the Ed25519 key that signs boarding passes, created if missing")
+	idsName := flag.String("ids", "pnr", "receipt ID generator: pnr, ulid or sequential (memory store only)")
+	authPath := flag.String("auth", "", "JSON file with the JWT keys and API keys callers authenticate with (default: no authentication)")
+	policyPath := flag.String("policy", "", "JSON file with the roles allowed to call each method, used with -auth (default: built-in policy)")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "seed for the random section allocator")
+	tlsCert := flag.String("tls-cert", "", "PEM certificate to serve TLS with, used with -tls-key (default: plaintext)")
+	tlsKey := flag.String("tls-key", "", "PEM private key of -tls-cert")
+	flag.Parse()
+
+	store, err := openStore(*storeKind, *dbPath)
+	if err != nil {
+		log.Fatalf("failed to open store: %v", err)
+	}
+	defer store.Close()
+
+	srv := newServer(store)
+	if *catalogPath != "" {
+		if srv.catalog, err = loadCatalog(*catalogPath); err != nil {
+			log.Fatalf("failed to load catalog: %v", err)
+		}
+	}
+	if *faresPath != "" {
+		if srv.fares, err = loadFareRules(*faresPath); err != nil {
+			log.Fatalf("failed to load fares: %v", err)
+		}
+	}
+	if *cancellationPath != "" {
+		if srv.cancellation, err = loadCancellationPolicy(*cancellationPath); err != nil {
+			log.Fatalf("failed to load cancellation policy: %v", err)
+		}
+	}
+	if srv.payments, err = newPaymentProvider(*paymentsName); err != nil {
+		log.Fatalf("failed to configure payments: %v", err)
+	}
+	if srv.passKey, err = boardingpass.LoadKey(*boardingKeyPath); err != nil {
+		log.Fatalf("failed to load boarding pass key: %v", err)
+	}
+	log.Printf("Boarding passes are signed with public key %s", boardingpass.EncodePublicKey(srv.passKey.Public().(ed25519.PublicKey)))
+	srv.paymentTimeout = *paymentTimeout
+	srv.holdTTL = *holdTTL
+	go srv.reapHolds(context.Background(), 10*time.Second)
+	if srv.ids, err = newIDGenerator(*idsName, *storeKind); err != nil {
+		log.Fatalf("failed to configure IDs: %v", err)
+	}
+	if srv.allocator, err = newSectionAllocator(*allocatorName, *seed); err != nil {
+		log.Fatalf("failed to configure allocator: %v", err)
+	}
+
 	lis, err := net.Listen("tcp", ":50056")
 	if err != nil {
 		log.Fatalf("failed to listen: %v", err)
 	}
-	s := grpc.NewServer()
-	pb.RegisterTicketServiceServer(s, newServer())
+	var opts []grpc.ServerOption
+	tlsOpt, err := serverCredentials(*tlsCert, *tlsKey)
+	if err != nil {
+		log.Fatalf("failed to configure TLS: %v", err)
+	}
+	if tlsOpt != nil {
+		opts = append(opts, tlsOpt)
+	} else {
+		log.Println("TLS is disabled: calls and their tokens travel in plaintext, and clients need -insecure")
+	}
+	if *authPath != "" {
+		auth, err := loadAuthenticator(*authPath)
+		if err != nil {
+			log.Fatalf("failed to configure authentication: %v", err)
+		}
+		if *policyPath != "" {
+			if srv.policy, err = loadAccessPolicy(*policyPath); err != nil {
+				log.Fatalf("failed to load access policy: %v", err)
+			}
+		}
+		// Callers are known before the policy is checked
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(auth.unaryInterceptor, srv.authorizeUnary),
+			grpc.ChainStreamInterceptor(auth.streamInterceptor, srv.authorizeStream))
+	} else if *policyPath != "" {
+		log.Fatal("-policy needs -auth to know who is calling")
+	} else {
+		log.Println("Authentication is disabled: anyone who can reach the server can call every method")
+	}
+	s := grpc.NewServer(opts...)
+	pb.RegisterTicketServiceServer(s, srv)
 	log.Println("Starting server on :50056")
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
@@ -2,23 +2,34 @@ package main
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-func newTestServer() *server {
-	return &server{
-		receipts:    make(map[string]*pb.ReceiptResponse),
-		userSeats:   make(map[string]string),
-		sectionA:    make(map[string]string),
-		sectionB:    make(map[string]string),
-		seatCounter: 0, // Initialize the seat counter
-	}
+const testDepartureID = "ES9010-20300901"
 
+func newTestServer() *server {
+	s := newServer(newMemoryStore())
+	// Predictable receipt IDs keep the tests readable
+	s.ids = &sequentialIDs{}
+	return s
 }
 func TestPurchaseTicket(t *testing.T) {
 	server := newTestServer()
@@ -26,13 +37,15 @@ func TestPurchaseTicket(t *testing.T) {
 		name         string
 		req          *pb.PurchaseRequest
 		expectedResp *pb.PurchaseResponse
-		expectedErr  error
+		expectedCode codes.Code
+		expectedErr  string
 	}{
 		{
 			name: "successful purchase",
 			req: &pb.PurchaseRequest{
-				From: "London",
-				To:   "France",
+				From:        "London",
+				To:          "Paris",
+				DepartureId: testDepartureID,
 				User: &pb.User{
 					FirstName: "John",
 					LastName:  "Doe",
@@ -40,13 +53,13 @@ func TestPurchaseTicket(t *testing.T) {
 				},
 			},
 			expectedResp: &pb.PurchaseResponse{ReceiptId: "rec-1"},
-			expectedErr:  nil,
+			expectedCode: codes.OK,
 		},
 		{
 			name: "failure purchase - No from value",
 			req: &pb.PurchaseRequest{
 				From: "",
-				To:   "France",
+				To:   "Paris",
 				User: &pb.User{
 					FirstName: "John",
 					LastName:  "Doe",
@@ -54,26 +67,28 @@ func TestPurchaseTicket(t *testing.T) {
 				},
 			},
 			expectedResp: nil,
-			expectedErr:  fmt.Errorf("from and to fields are required"),
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "from and to fields are required",
 		},
 		{
 			name: "failure purchase - no User details",
 			req: &pb.PurchaseRequest{
 				From: "London",
-				To:   "France",
+				To:   "Paris",
 				User: nil,
 			},
 			expectedResp: nil,
-			expectedErr:  fmt.Errorf("user information is required"),
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "user information is required",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			resp, err := server.PurchaseTicket(context.Background(), tt.req)
+			assert.Equal(t, tt.expectedCode, status.Code(err))
 			if err != nil {
-
-				assert.Equal(t, tt.expectedErr, err)
+				assert.Contains(t, status.Convert(err).Message(), tt.expectedErr)
 			}
 			assert.Equal(t, tt.expectedResp, resp)
 		})
@@ -85,8 +100,9 @@ func TestGetReceipt(t *testing.T) {
 	s := newTestServer()
 	// First, simulate a ticket purchase to generate a receipt
 	req := &pb.PurchaseRequest{
-		From: "London",
-		To:   "France",
+		From:        "London",
+		To:          "Paris",
+		DepartureId: testDepartureID,
 		User: &pb.User{
 			FirstName: "Jane",
 			LastName:  "Doe",
@@ -105,10 +121,10 @@ func TestGetReceipt(t *testing.T) {
 }
 
 type testInput struct {
-	name            string
-	initialUser     *pb.User
-	removeEmail     string
-	expectedSuccess bool
+	name         string
+	initialUser  *pb.User
+	removeEmail  string
+	expectedCode codes.Code
 }
 
 func TestRemoveUser(t *testing.T) {
@@ -122,14 +138,14 @@ func TestRemoveUser(t *testing.T) {
 				LastName:  "Brown",
 				Email:     "bob.brown@example.com",
 			},
-			removeEmail:     "bob.brown@example.com",
-			expectedSuccess: true,
+			removeEmail:  "bob.brown@example.com",
+			expectedCode: codes.OK,
 		},
 		{
-			name:            "Remove non-existent user",
-			initialUser:     nil, // No initial user
-			removeEmail:     "nonexistent.user@example.com",
-			expectedSuccess: false,
+			name:         "Remove non-existent user",
+			initialUser:  nil, // No initial user
+			removeEmail:  "nonexistent.user@example.com",
+			expectedCode: codes.NotFound,
 		},
 	}
 
@@ -138,9 +154,10 @@ func TestRemoveUser(t *testing.T) {
 			if tt.initialUser != nil {
 				// Simulate a ticket purchase for the initial user
 				req := &pb.PurchaseRequest{
-					From: "London",
-					To:   "France",
-					User: tt.initialUser,
+					From:        "London",
+					To:          "Paris",
+					DepartureId: testDepartureID,
+					User:        tt.initialUser,
 				}
 				_, err := s.PurchaseTicket(context.Background(), req)
 				require.NoError(t, err)
@@ -148,8 +165,431 @@ func TestRemoveUser(t *testing.T) {
 
 			// Test removal
 			removeResp, err := s.RemoveUser(context.Background(), &pb.RemoveUserRequest{Email: tt.removeEmail})
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if err == nil {
+				assert.True(t, removeResp.Success)
+			}
+		})
+	}
+}
+
+// purchaseSeats buys a ticket on testDepartureID for each preferred section and
+// returns the seats that were assigned.
+func purchaseSeats(t *testing.T, s *server, preferred ...string) []string {
+	var seats []string
+	for i, section := range preferred {
+		resp, err := s.PurchaseTicket(context.Background(), &pb.PurchaseRequest{
+			From:             "London",
+			To:               "Paris",
+			DepartureId:      testDepartureID,
+			User:             &pb.User{Email: fmt.Sprintf("passenger%d@example.com", i)},
+			PreferredSection: section,
+		})
+		require.NoError(t, err)
+		receipt, err := s.GetReceipt(context.Background(), &pb.ReceiptRequest{ReceiptId: resp.ReceiptId})
+		require.NoError(t, err)
+		seats = append(seats, receipt.Seat)
+	}
+	return seats
+}
+
+func TestSectionAllocators(t *testing.T) {
+	tests := []struct {
+		name      string
+		allocator string
+		preferred []string
+		expected  []string
+	}{
+		{
+			name:      "balanced alternates sections",
+			allocator: "balanced",
+			preferred: []string{"", "", "", ""},
+			expected:  []string{"A1", "B1", "A2", "B2"},
+		},
+		{
+			name:      "fill-first fills SectionA before SectionB",
+			allocator: "fill-first",
+			preferred: []string{"", "", "", ""},
+			expected:  []string{"A1", "A2", "B1", "B2"},
+		},
+		{
+			name:      "preference honoured until the section is full",
+			allocator: "preference",
+			preferred: []string{"SectionB", "SectionB", "SectionB", ""},
+			expected:  []string{"B1", "B2", "A1", "A2"},
+		},
+		{
+			name:      "balanced honours preference",
+			allocator: "balanced",
+			preferred: []string{"SectionB", "SectionB", ""},
+			expected:  []string{"B1", "B2", "A1"},
+		},
+		{
+			name:      "fill-first honours preference",
+			allocator: "fill-first",
+			preferred: []string{"SectionB", "", ""},
+			expected:  []string{"B1", "A1", "A2"},
+		},
+		{
+			name:      "random honours preference",
+			allocator: "random",
+			preferred: []string{"SectionB", "SectionB"},
+			expected:  []string{"B1", "B2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSmallTrainServer()
+			allocator, err := newSectionAllocator(tt.allocator, 0)
 			require.NoError(t, err)
-			assert.Equal(t, tt.expectedSuccess, removeResp.Success)
+			s.allocator = allocator
+
+			assert.Equal(t, tt.expected, purchaseSeats(t, s, tt.preferred...))
 		})
 	}
 }
+
+func TestUnknownPreferredSection(t *testing.T) {
+	s := newTestServer()
+	_, err := s.PurchaseTicket(context.Background(), &pb.PurchaseRequest{From: "London", To: "Paris", DepartureId: testDepartureID,
+		User: &pb.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"}, PreferredSection: "SectionZ"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	fields, _ := errorDetails(err)
+	assert.Equal(t, []string{"preferred_section"}, fields)
+}
+
+func TestRandomAllocatorIsSeeded(t *testing.T) {
+	run := func(seed int64) []string {
+		s := newTestServer()
+		s.allocator = newRandomAllocator(seed)
+		return purchaseSeats(t, s, make([]string, 10)...)
+	}
+
+	assert.Equal(t, run(42), run(42))
+
+	// Every seat still comes from a section with free seats.
+	s := newSmallTrainServer()
+	s.allocator = newRandomAllocator(7)
+	assert.ElementsMatch(t, []string{"A1", "A2", "B1", "B2"}, purchaseSeats(t, s, make([]string, 4)...))
+}
+
+func TestNewSectionAllocatorUnknown(t *testing.T) {
+	_, err := newSectionAllocator("round-robin", 0)
+	assert.Error(t, err)
+}
+
+// errorDetails returns the BadRequest field names and ErrorInfo reason carried by err.
+func errorDetails(err error) (fields []string, reason string) {
+	for _, detail := range status.Convert(err).Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			for _, v := range d.FieldViolations {
+				fields = append(fields, v.Field)
+			}
+		case *errdetails.ErrorInfo:
+			reason = d.Reason
+		}
+	}
+	return fields, reason
+}
+
+func TestErrorDetails(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	user := &pb.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"}
+
+	_, err := s.PurchaseTicket(ctx, &pb.PurchaseRequest{From: "Atlantis", To: "Lemuria", DepartureId: testDepartureID, User: user})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	fields, _ := errorDetails(err)
+	assert.Equal(t, []string{"from", "to"}, fields)
+
+	_, err = s.PurchaseTicket(ctx, &pb.PurchaseRequest{From: "London", To: "Paris", DepartureId: "ES0000-20300901", User: user})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, reason := errorDetails(err)
+	assert.Equal(t, reasonDepartureNotFound, reason)
+
+	_, err = s.PurchaseTicket(ctx, &pb.PurchaseRequest{From: "London", To: "Paris", DepartureId: testDepartureID, User: user})
+	require.NoError(t, err)
+	_, err = s.PurchaseTicket(ctx, &pb.PurchaseRequest{From: "London", To: "Paris", DepartureId: testDepartureID, User: user})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, reason = errorDetails(err)
+	assert.Equal(t, reasonAlreadyBooked, reason)
+
+	_, err = s.GetReceipt(ctx, &pb.ReceiptRequest{ReceiptId: "rec-99"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, reason = errorDetails(err)
+	assert.Equal(t, reasonReceiptNotFound, reason)
+
+	_, err = s.ViewUsersBySection(ctx, &pb.ViewUsersRequest{Section: "SectionC"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	fields, _ = errorDetails(err)
+	assert.Equal(t, []string{"section"}, fields)
+
+	_, err = s.ModifySeat(ctx, &pb.ModifySeatRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	fields, _ = errorDetails(err)
+	assert.Equal(t, []string{"receipt_id", "new_seat"}, fields)
+
+	_, err = s.ModifySeat(ctx, &pb.ModifySeatRequest{Email: "nobody@example.com", NewSeat: "A1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, reason = errorDetails(err)
+	assert.Equal(t, reasonUserNotFound, reason)
+
+	_, err = s.ModifySeat(ctx, &pb.ModifySeatRequest{Email: user.Email, NewSeat: "Z9"})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, reason = errorDetails(err)
+	assert.Equal(t, reasonSeatNotFound, reason)
+}
+
+func TestPurchaseDepartedTrain(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	// ES9010 leaves London at 08:01 and calls at Lille at 09:23
+	s.now = func() time.Time { return time.Date(2030, 9, 1, 8, 30, 0, 0, time.UTC) }
+
+	_, err := s.PurchaseTicket(ctx, &pb.PurchaseRequest{From: "London", To: "Paris", DepartureId: testDepartureID,
+		User: &pb.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"}})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, reason := errorDetails(err)
+	assert.Equal(t, reasonAlreadyDeparted, reason)
+
+	// Passengers boarding further down the line can still buy
+	_, err = s.PurchaseTicket(ctx, &pb.PurchaseRequest{From: "Lille", To: "Paris", DepartureId: testDepartureID,
+		User: &pb.User{FirstName: "Jane", LastName: "Doe", Email: "jane.doe@example.com"}})
+	assert.NoError(t, err)
+}
+
+func TestMultipleTicketsPerUser(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	user := &pb.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"}
+
+	var receiptIDs []string
+	for _, departureID := range []string{"ES9024-20300902", testDepartureID, "ES9002-20300902"} {
+		resp, err := s.PurchaseTicket(ctx, &pb.PurchaseRequest{From: "London", To: "Paris", DepartureId: departureID, User: user})
+		require.NoError(t, err)
+		receiptIDs = append(receiptIDs, resp.ReceiptId)
+	}
+
+	list, err := s.ListMyTickets(ctx, &pb.ListMyTicketsRequest{Email: user.Email})
+	require.NoError(t, err)
+	var departures []string
+	for _, receipt := range list.Receipts {
+		departures = append(departures, receipt.DepartureId)
+	}
+	assert.Equal(t, []string{testDepartureID, "ES9002-20300902", "ES9024-20300902"}, departures)
+
+	// The user's email no longer identifies a single ticket.
+	_, err = s.ModifySeat(ctx, &pb.ModifySeatRequest{Email: user.Email, NewSeat: "B5"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = s.ModifySeat(ctx, &pb.ModifySeatRequest{ReceiptId: receiptIDs[1], NewSeat: "B5"})
+	require.NoError(t, err)
+	for i, receiptID := range receiptIDs {
+		receipt, err := s.GetReceipt(ctx, &pb.ReceiptRequest{ReceiptId: receiptID})
+		require.NoError(t, err)
+		if i == 1 {
+			assert.Equal(t, "B5", receipt.Seat)
+		} else {
+			assert.NotEqual(t, "B5", receipt.Seat)
+		}
+	}
+
+	cancelResp, err := s.CancelTicket(ctx, &pb.CancelTicketRequest{ReceiptId: receiptIDs[1]})
+	require.NoError(t, err)
+	assert.True(t, cancelResp.Success)
+	cancelled, err := s.GetReceipt(ctx, &pb.ReceiptRequest{ReceiptId: receiptIDs[1]})
+	require.NoError(t, err)
+	assert.Equal(t, pb.BookingStatus_BOOKING_STATUS_CANCELLED, cancelled.Status)
+	_, err = s.CancelTicket(ctx, &pb.CancelTicketRequest{ReceiptId: receiptIDs[1]})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = s.CancelTicket(ctx, &pb.CancelTicketRequest{ReceiptId: "rec-99"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// RemoveUser cancels every remaining ticket, not just the first.
+	removeResp, err := s.RemoveUser(ctx, &pb.RemoveUserRequest{Email: user.Email})
+	require.NoError(t, err)
+	assert.Len(t, removeResp.Refunds, 2)
+	list, err = s.ListMyTickets(ctx, &pb.ListMyTicketsRequest{Email: user.Email})
+	require.NoError(t, err)
+	require.Len(t, list.Receipts, 3)
+	for _, receipt := range list.Receipts {
+		assert.Equal(t, pb.BookingStatus_BOOKING_STATUS_CANCELLED, receipt.Status)
+	}
+	_, err = s.RemoveUser(ctx, &pb.RemoveUserRequest{Email: user.Email})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	for _, section := range []string{"SectionA", "SectionB"} {
+		resp, err := s.ViewUsersBySection(ctx, &pb.ViewUsersRequest{Section: section})
+		require.NoError(t, err)
+		assert.Empty(t, resp.UserSeats)
+	}
+}
+
+func TestGroupPurchase(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	booker := &pb.User{FirstName: "Jane", LastName: "Doe", Email: "jane.doe@example.com"}
+	party := []*pb.User{booker, {FirstName: "Kid", LastName: "Doe", Email: "kid.doe@example.com"}, {FirstName: "Gran", LastName: "Doe", Email: "gran.doe@example.com"}}
+	purchase := func(passengers ...*pb.User) (*pb.PurchaseResponse, error) {
+		return s.PurchaseTicket(ctx, &pb.PurchaseRequest{From: "London", To: "Paris", DepartureId: testDepartureID, User: booker, Passengers: passengers})
+	}
+
+	resp, err := purchase(party...)
+	require.NoError(t, err)
+	receipt, err := s.GetReceipt(ctx, &pb.ReceiptRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+	require.Len(t, receipt.SeatLines, 3)
+	for i, line := range receipt.SeatLines {
+		assert.Equal(t, party[i].Email, line.Passenger.Email)
+		assert.Equal(t, "SectionA", line.Section)
+		assert.Equal(t, fmt.Sprintf("A%d", i+1), line.Seat)
+	}
+	assert.Equal(t, "A1", receipt.Seat)
+	require.Len(t, receipt.Fare.Lines, 3)
+	assert.Equal(t, receipt.Fare.Total, receipt.PricePaid)
+
+	// Every passenger sees the shared ticket.
+	list, err := s.ListMyTickets(ctx, &pb.ListMyTicketsRequest{Email: "kid.doe@example.com"})
+	require.NoError(t, err)
+	require.Len(t, list.Receipts, 1)
+	assert.Equal(t, resp.ReceiptId, list.Receipts[0].ReceiptId)
+
+	// Nobody in a party may already hold a seat on the departure, nor be listed twice.
+	_, err = purchase(&pb.User{Email: "new@example.com"}, &pb.User{Email: "kid.doe@example.com"})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+	_, err = purchase(&pb.User{Email: "x@example.com"}, &pb.User{Email: "x@example.com"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// A party that cannot be seated takes no seats at all.
+	var crowd []*pb.User
+	for i := 0; i < 38; i++ {
+		crowd = append(crowd, &pb.User{Email: fmt.Sprintf("crowd%d@example.com", i)})
+	}
+	_, err = purchase(crowd...)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	_, reason := errorDetails(err)
+	assert.Equal(t, reasonNotEnoughSeats, reason)
+	dep, _ := s.catalog.departure(testDepartureID)
+	seats, err := s.seatMap(dep)
+	require.NoError(t, err)
+	assert.Len(t, seats.occupied, 3)
+
+	// Moving one passenger requires saying which seat is theirs.
+	_, err = s.ModifySeat(ctx, &pb.ModifySeatRequest{ReceiptId: resp.ReceiptId, NewSeat: "B1"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = s.ModifySeat(ctx, &pb.ModifySeatRequest{ReceiptId: resp.ReceiptId, CurrentSeat: "A2", NewSeat: "B1"})
+	require.NoError(t, err)
+	sectionB, err := s.ViewUsersBySection(ctx, &pb.ViewUsersRequest{Section: "SectionB"})
+	require.NoError(t, err)
+	require.Len(t, sectionB.UserSeats, 1)
+	assert.Equal(t, "kid.doe@example.com", sectionB.UserSeats[0].User.Email)
+
+	// Removing a passenger only frees their seat; removing the booker cancels the ticket.
+	_, err = s.RemoveUser(ctx, &pb.RemoveUserRequest{Email: "gran.doe@example.com"})
+	require.NoError(t, err)
+	receipt, err = s.GetReceipt(ctx, &pb.ReceiptRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+	assert.Len(t, receipt.SeatLines, 2)
+	assert.Len(t, receipt.CancelledSeatLines, 1)
+	_, err = s.RemoveUser(ctx, &pb.RemoveUserRequest{Email: booker.Email})
+	require.NoError(t, err)
+	receipt, err = s.GetReceipt(ctx, &pb.ReceiptRequest{ReceiptId: resp.ReceiptId})
+	require.NoError(t, err)
+	assert.Equal(t, pb.BookingStatus_BOOKING_STATUS_CANCELLED, receipt.Status)
+	assert.Len(t, receipt.Refunds, 2)
+	seats, err = s.seatMap(dep)
+	require.NoError(t, err)
+	assert.Empty(t, seats.occupied)
+}
+
+func TestViewUsersManifest(t *testing.T) {
+	s := newTestServer()
+	ctx := context.Background()
+	buy := func(departureID, seat string, user *pb.User) string {
+		resp, err := s.PurchaseTicket(ctx, &pb.PurchaseRequest{From: "London", To: "Paris", DepartureId: departureID, User: user, PreferredSeat: seat})
+		require.NoError(t, err)
+		return resp.ReceiptId
+	}
+	zoe := buy(testDepartureID, "A10", &pb.User{FirstName: "Zoe", LastName: "Adams", Email: "zoe@example.com"})
+	buy(testDepartureID, "A2", &pb.User{FirstName: "Ann", LastName: "Brown", Email: "ann@example.com"})
+	buy("ES9024-20300901", "A1", &pb.User{FirstName: "Bob", LastName: "Brown", Email: "bob@example.com"})
+
+	seats := func(resp *pb.ViewUsersResponse) []string {
+		var seats []string
+		for _, us := range resp.UserSeats {
+			seats = append(seats, us.DepartureId+"/"+us.Seat)
+		}
+		return seats
+	}
+	resp, err := s.ViewUsersBySection(ctx, &pb.ViewUsersRequest{Section: "SectionA"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{testDepartureID + "/A2", testDepartureID + "/A10", "ES9024-20300901/A1"}, seats(resp))
+	last := resp.UserSeats[1]
+	assert.Equal(t, "Zoe", last.User.FirstName)
+	assert.Equal(t, zoe, last.ReceiptId)
+	assert.Equal(t, "SectionA", last.Section)
+	assert.Equal(t, "LON-PAR", last.RouteId)
+	assert.Equal(t, "London", last.From.Name)
+	assert.Equal(t, "Paris", last.To.Name)
+
+	resp, err = s.ViewUsersBySection(ctx, &pb.ViewUsersRequest{Section: "SectionA", OrderBy: pb.ManifestOrder_MANIFEST_ORDER_NAME})
+	require.NoError(t, err)
+	assert.Equal(t, []string{testDepartureID + "/A10", testDepartureID + "/A2", "ES9024-20300901/A1"}, seats(resp))
+
+	resp, err = s.ViewUsersBySection(ctx, &pb.ViewUsersRequest{Section: "SectionA", DepartureId: "ES9024-20300901"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ES9024-20300901/A1"}, seats(resp))
+	resp, err = s.ViewUsersBySection(ctx, &pb.ViewUsersRequest{Section: "SectionA", RouteId: "PAR-LON"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.UserSeats)
+
+	_, err = s.ViewUsersBySection(ctx, &pb.ViewUsersRequest{Section: "SectionA", DepartureId: "XX0000-20300901"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = s.ViewUsersBySection(ctx, &pb.ViewUsersRequest{Section: "SectionA", RouteId: "LON-NYC"})
+	fields, _ := errorDetails(err)
+	assert.Equal(t, []string{"route_id"}, fields)
+}
+
+// writeTestCertificate writes a self-signed certificate for localhost and its
+// key to dir, returning their paths.
+func writeTestCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certFile, keyFile
+}
+
+func TestServerCredentials(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t, t.TempDir())
+
+	opt, err := serverCredentials(certFile, keyFile)
+	assert.NoError(t, err)
+	assert.NotNil(t, opt)
+
+	// Without either flag the server stays in plaintext
+	opt, err = serverCredentials("", "")
+	assert.NoError(t, err)
+	assert.Nil(t, opt)
+
+	_, err = serverCredentials(certFile, "")
+	assert.Error(t, err)
+	_, err = serverCredentials("", keyFile)
+	assert.Error(t, err)
+	_, err = serverCredentials(keyFile, certFile)
+	assert.Error(t, err)
+}
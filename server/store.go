@@ -0,0 +1,183 @@
+package main
+
+import (
+	"errors"
+	"sort"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"google.golang.org/protobuf/proto"
+)
+
+// errNotFound is returned by a Store when the requested record does not exist.
+var errNotFound = errors.New("not found")
+
+// Store persists the bookings made through the TicketService. Every ticket
+// is identified by its receipt ID; a user may hold any number of tickets.
+//
+// Implementations are not required to be safe for concurrent use; the server
+// serialises every call with its own mutex.
+type Store interface {
+	// SaveReceipt creates or replaces the receipt stored under receiptID.
+	SaveReceipt(receiptID string, receipt *pb.ReceiptResponse) error
+	// Receipt returns the receipt stored under receiptID, or errNotFound.
+	Receipt(receiptID string) (*pb.ReceiptResponse, error)
+	// ReceiptIDsForEmail returns the IDs of the receipts booked by or for
+	// email, in lexical order.
+	ReceiptIDsForEmail(email string) ([]string, error)
+
+	// SaveTicket saves receipt under its receipt ID together with its seats,
+	// all at once or not at all: the seats of its seat lines are taken on its
+	// departure and listed under their sections in place of any it held
+	// before, and the released seats, which it has given up, are freed.
+	SaveTicket(receipt *pb.ReceiptResponse, released []string) error
+	// SectionSeats returns the seats in section keyed by receipt ID, in
+	// lexical order.
+	SectionSeats(section string) (map[string][]string, error)
+	// OccupiedSeats returns the taken seats on departureID keyed by seat ID,
+	// mapped to the receipt ID of the ticket in the seat.
+	OccupiedSeats(departureID string) (map[string]string, error)
+
+	// Close releases any resources held by the store.
+	Close() error
+}
+
+// memoryStore keeps bookings in maps and loses them when the process exits.
+type memoryStore struct {
+	receipts     map[string]*pb.ReceiptResponse
+	userReceipts map[string]map[string]bool
+	sectionA     map[string][]string
+	sectionB     map[string][]string
+	seatMaps     map[string]map[string]string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		receipts:     make(map[string]*pb.ReceiptResponse),
+		userReceipts: make(map[string]map[string]bool),
+		sectionA:     make(map[string][]string),
+		sectionB:     make(map[string][]string),
+		seatMaps:     make(map[string]map[string]string),
+	}
+}
+
+func (m *memoryStore) SaveReceipt(receiptID string, receipt *pb.ReceiptResponse) error {
+	m.unindexReceipt(receiptID)
+	m.receipts[receiptID] = proto.Clone(receipt).(*pb.ReceiptResponse)
+	for _, email := range receiptEmails(receipt) {
+		if m.userReceipts[email] == nil {
+			m.userReceipts[email] = make(map[string]bool)
+		}
+		m.userReceipts[email][receiptID] = true
+	}
+	return nil
+}
+
+func (m *memoryStore) Receipt(receiptID string) (*pb.ReceiptResponse, error) {
+	receipt, exists := m.receipts[receiptID]
+	if !exists {
+		return nil, errNotFound
+	}
+	return proto.Clone(receipt).(*pb.ReceiptResponse), nil
+}
+
+func (m *memoryStore) ReceiptIDsForEmail(email string) ([]string, error) {
+	var receiptIDs []string
+	for receiptID := range m.userReceipts[email] {
+		receiptIDs = append(receiptIDs, receiptID)
+	}
+	sort.Strings(receiptIDs)
+	return receiptIDs, nil
+}
+
+func (m *memoryStore) SaveTicket(receipt *pb.ReceiptResponse, released []string) error {
+	// Check everything that can fail before changing anything
+	for _, line := range receipt.SeatLines {
+		if m.section(line.Section) == nil {
+			return errNotFound
+		}
+	}
+
+	receiptID := receipt.ReceiptId
+	m.SaveReceipt(receiptID, receipt)
+	seats := m.seatMaps[receipt.DepartureId]
+	if seats == nil {
+		seats = make(map[string]string)
+		m.seatMaps[receipt.DepartureId] = seats
+	}
+	for _, seat := range released {
+		delete(seats, seat)
+	}
+	delete(m.sectionA, receiptID)
+	delete(m.sectionB, receiptID)
+	for _, line := range receipt.SeatLines {
+		seats[line.Seat] = receiptID
+		sectionSeats := m.section(line.Section)
+		sectionSeats[receiptID] = append(sectionSeats[receiptID], line.Seat)
+		sort.Strings(sectionSeats[receiptID])
+	}
+	return nil
+}
+
+func (m *memoryStore) SectionSeats(section string) (map[string][]string, error) {
+	sectionSeats := m.section(section)
+	if sectionSeats == nil {
+		return nil, errNotFound
+	}
+	seats := make(map[string][]string, len(sectionSeats))
+	for receiptID, ids := range sectionSeats {
+		seats[receiptID] = append([]string(nil), ids...)
+	}
+	return seats, nil
+}
+
+func (m *memoryStore) OccupiedSeats(departureID string) (map[string]string, error) {
+	seats := make(map[string]string, len(m.seatMaps[departureID]))
+	for seat, receiptID := range m.seatMaps[departureID] {
+		seats[seat] = receiptID
+	}
+	return seats, nil
+}
+
+func (m *memoryStore) Close() error {
+	return nil
+}
+
+func (m *memoryStore) section(section string) map[string][]string {
+	switch section {
+	case "SectionA":
+		return m.sectionA
+	case "SectionB":
+		return m.sectionB
+	}
+	return nil
+}
+
+// unindexReceipt drops receiptID from the per-user index.
+func (m *memoryStore) unindexReceipt(receiptID string) {
+	old, exists := m.receipts[receiptID]
+	if !exists {
+		return
+	}
+	for _, email := range receiptEmails(old) {
+		delete(m.userReceipts[email], receiptID)
+		if len(m.userReceipts[email]) == 0 {
+			delete(m.userReceipts, email)
+		}
+	}
+}
+
+// receiptEmails returns the distinct emails of the booker and every passenger
+// on receipt, including those whose seats were cancelled.
+func receiptEmails(receipt *pb.ReceiptResponse) []string {
+	emails := []string{receipt.GetUser().GetEmail()}
+	seen := map[string]bool{emails[0]: true}
+	lines := append(append([]*pb.SeatLine(nil), receipt.GetSeatLines()...), receipt.GetCancelledSeatLines()...)
+	for _, line := range lines {
+		email := line.GetPassenger().GetEmail()
+		if email != "" && !seen[email] {
+			emails = append(emails, email)
+			seen[email] = true
+		}
+	}
+	return emails
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestBoltStore(t *testing.T) *boltStore {
+	store, err := openBoltStore(filepath.Join(t.TempDir(), "tickets.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestStores(t *testing.T) {
+	stores := map[string]func(t *testing.T) Store{
+		"memory": func(t *testing.T) Store { return newMemoryStore() },
+		"bolt":   func(t *testing.T) Store { return newTestBoltStore(t) },
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+
+			receipt := &pb.ReceiptResponse{
+				From:      "London",
+				To:        "France",
+				User:      &pb.User{FirstName: "John", LastName: "Doe", Email: "john.doe@example.com"},
+				PricePaid: &pb.Money{CurrencyCode: "GBP", Units: 20},
+				Seat:      "Seat-1",
+			}
+			require.NoError(t, store.SaveReceipt("rec-1", receipt))
+			got, err := store.Receipt("rec-1")
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(receipt, got))
+
+			_, err = store.Receipt("rec-2")
+			assert.ErrorIs(t, err, errNotFound)
+
+			require.NoError(t, store.SaveReceipt("rec-2", &pb.ReceiptResponse{User: receipt.User, Seat: "Seat-2"}))
+			require.NoError(t, store.SaveReceipt("rec-3", &pb.ReceiptResponse{User: &pb.User{Email: "jane.doe@example.com"}}))
+			receiptIDs, err := store.ReceiptIDsForEmail("john.doe@example.com")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"rec-1", "rec-2"}, receiptIDs)
+
+			// Handing a ticket to another passenger moves it between users.
+			require.NoError(t, store.SaveReceipt("rec-2", &pb.ReceiptResponse{User: &pb.User{Email: "jane.doe@example.com"}}))
+			receiptIDs, err = store.ReceiptIDsForEmail("john.doe@example.com")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"rec-1"}, receiptIDs)
+			receiptIDs, err = store.ReceiptIDsForEmail("jane.doe@example.com")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"rec-2", "rec-3"}, receiptIDs)
+
+			// Passengers on a party ticket can find it by their own email.
+			require.NoError(t, store.SaveReceipt("rec-4", &pb.ReceiptResponse{
+				User:      &pb.User{Email: "john.doe@example.com"},
+				SeatLines: []*pb.SeatLine{{Passenger: &pb.User{Email: "john.doe@example.com"}}, {Passenger: &pb.User{Email: "kid.doe@example.com"}}},
+			}))
+			receiptIDs, err = store.ReceiptIDsForEmail("kid.doe@example.com")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"rec-4"}, receiptIDs)
+
+			// Tickets take their seats and are listed under their sections
+			rec1 := &pb.ReceiptResponse{ReceiptId: "rec-1", DepartureId: testDepartureID, User: receipt.User,
+				SeatLines: []*pb.SeatLine{{Seat: "A1", Section: "SectionA"}}}
+			require.NoError(t, store.SaveTicket(rec1, nil))
+			rec1.SeatLines = []*pb.SeatLine{{Seat: "B3", Section: "SectionB"}, {Seat: "B1", Section: "SectionB"}}
+			require.NoError(t, store.SaveTicket(rec1, []string{"A1"}))
+			rec2 := &pb.ReceiptResponse{ReceiptId: "rec-2", DepartureId: testDepartureID, User: &pb.User{Email: "jane.doe@example.com"},
+				SeatLines: []*pb.SeatLine{{Seat: "B2", Section: "SectionB"}}}
+			require.NoError(t, store.SaveTicket(rec2, nil))
+
+			seats, err := store.SectionSeats("SectionB")
+			require.NoError(t, err)
+			assert.Equal(t, map[string][]string{"rec-1": {"B1", "B3"}, "rec-2": {"B2"}}, seats)
+			seats, err = store.SectionSeats("SectionA")
+			require.NoError(t, err)
+			assert.Empty(t, seats)
+			_, err = store.SectionSeats("SectionC")
+			assert.ErrorIs(t, err, errNotFound)
+			occupied, err := store.OccupiedSeats(testDepartureID)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"B1": "rec-1", "B2": "rec-2", "B3": "rec-1"}, occupied)
+			got, err = store.Receipt("rec-1")
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(rec1, got))
+
+			// A ticket without seats gives them all up
+			rec1.SeatLines = nil
+			require.NoError(t, store.SaveTicket(rec1, []string{"B1", "B3"}))
+			seats, err = store.SectionSeats("SectionB")
+			require.NoError(t, err)
+			assert.Equal(t, map[string][]string{"rec-2": {"B2"}}, seats)
+			occupied, err = store.OccupiedSeats(testDepartureID)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"B2": "rec-2"}, occupied)
+			occupied, err = store.OccupiedSeats("ES9024-20300901")
+			require.NoError(t, err)
+			assert.Empty(t, occupied)
+		})
+	}
+}
+
+func TestSaveTicketIsAtomic(t *testing.T) {
+	stores := map[string]func(t *testing.T) Store{
+		"memory": func(t *testing.T) Store { return newMemoryStore() },
+		"bolt":   func(t *testing.T) Store { return newTestBoltStore(t) },
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+			booked := &pb.ReceiptResponse{ReceiptId: "rec-1", DepartureId: testDepartureID, User: &pb.User{Email: "jane.doe@example.com"},
+				SeatLines: []*pb.SeatLine{{Seat: "A1", Section: "SectionA"}}}
+			require.NoError(t, store.SaveTicket(booked, nil))
+
+			// The unknown section fails the save after the receipt and the
+			// first seat would have been written
+			moved := proto.Clone(booked).(*pb.ReceiptResponse)
+			moved.SeatLines = []*pb.SeatLine{{Seat: "A2", Section: "SectionA"}, {Seat: "C1", Section: "SectionC"}}
+			assert.ErrorIs(t, store.SaveTicket(moved, []string{"A1"}), errNotFound)
+			fresh := &pb.ReceiptResponse{ReceiptId: "rec-2", DepartureId: testDepartureID, User: &pb.User{Email: "john.doe@example.com"},
+				SeatLines: []*pb.SeatLine{{Seat: "A3", Section: "SectionA"}, {Seat: "C1", Section: "SectionC"}}}
+			assert.ErrorIs(t, store.SaveTicket(fresh, nil), errNotFound)
+
+			// Neither save left anything behind
+			got, err := store.Receipt("rec-1")
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(booked, got))
+			_, err = store.Receipt("rec-2")
+			assert.ErrorIs(t, err, errNotFound)
+			receiptIDs, err := store.ReceiptIDsForEmail("john.doe@example.com")
+			require.NoError(t, err)
+			assert.Empty(t, receiptIDs)
+			occupied, err := store.OccupiedSeats(testDepartureID)
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"A1": "rec-1"}, occupied)
+			seats, err := store.SectionSeats("SectionA")
+			require.NoError(t, err)
+			assert.Equal(t, map[string][]string{"rec-1": {"A1"}}, seats)
+		})
+	}
+}
+
+func TestBoltStoreSurvivesRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tickets.db")
+	store, err := openBoltStore(path)
+	require.NoError(t, err)
+
+	s := newServer(store)
+	purchaseResp, err := s.PurchaseTicket(context.Background(), &pb.PurchaseRequest{
+		From:        "London",
+		To:          "Paris",
+		DepartureId: testDepartureID,
+		User:        &pb.User{FirstName: "Jane", LastName: "Doe", Email: "jane.doe@example.com"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	store, err = openBoltStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	s = newServer(store)
+	receipt, err := s.GetReceipt(context.Background(), &pb.ReceiptRequest{ReceiptId: purchaseResp.ReceiptId})
+	require.NoError(t, err)
+	assert.Equal(t, "jane.doe@example.com", receipt.User.Email)
+
+	occupied, err := store.OccupiedSeats(testDepartureID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{receipt.Seat: purchaseResp.ReceiptId}, occupied)
+}
+
+func TestReceiptIDsSurviveRestart(t *testing.T) {
+	// Sequential IDs would start again at rec-1 over the stored bookings
+	_, err := newIDGenerator("sequential", "bolt")
+	assert.Error(t, err)
+	_, err = newIDGenerator("sequential", "memory")
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "tickets.db")
+	bookings := map[string]string{}
+	for _, email := range []string{"jane.doe@example.com", "john.doe@example.com"} {
+		store, err := openBoltStore(path)
+		require.NoError(t, err)
+		s := newServer(store)
+		s.ids, err = newIDGenerator("pnr", "bolt")
+		require.NoError(t, err)
+		resp, err := purchaseWithToken(s, email, "", "")
+		require.NoError(t, err)
+		bookings[resp.ReceiptId] = email
+		require.NoError(t, store.Close())
+	}
+
+	store, err := openBoltStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+	require.Len(t, bookings, 2)
+	for receiptID, email := range bookings {
+		receipt, err := store.Receipt(receiptID)
+		require.NoError(t, err)
+		assert.Equal(t, email, receipt.User.Email)
+	}
+}
@@ -0,0 +1,356 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// waitlistEntry is a passenger queueing for a seat on a sold-out departure.
+// Like holds, the waitlist lives only in the server's memory. Entries are
+// dropped once they are booked, left or expired.
+type waitlistEntry struct {
+	id          string
+	departureID string
+	section     string // empty if any section will do
+	user        *pb.User
+	priority    int32
+	seq         int // join order, breaking ties between equal priorities
+	joinedAt    time.Time
+	status      pb.WaitlistStatus
+	holdID      string // set once promoted
+	// changed is closed, and replaced, whenever the entry or its place in the
+	// queue changes, waking WatchWaitlist streams.
+	changed chan struct{}
+}
+
+// ahead reports whether e is served before other.
+func (e *waitlistEntry) ahead(other *waitlistEntry) bool {
+	if e.priority != other.priority {
+		return e.priority > other.priority
+	}
+	return e.seq < other.seq
+}
+
+func (s *server) JoinWaitlist(ctx context.Context, req *pb.JoinWaitlistRequest) (*pb.JoinWaitlistResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	if req.DepartureId == "" {
+		violations = append(violations, fieldViolation("departure_id", "departure_id is required"))
+	}
+	if req.User.GetEmail() == "" {
+		violations = append(violations, fieldViolation("user.email", "user email is required"))
+	}
+	if len(violations) > 0 {
+		return nil, badRequest(violations...)
+	}
+	dep, ok := s.catalog.departure(req.DepartureId)
+	if !ok {
+		return nil, errorInfo(codes.NotFound, reasonDepartureNotFound, map[string]string{"departure_id": req.DepartureId},
+			"departure %q not found", req.DepartureId)
+	}
+	// An entry could never be served once the train has left the passenger's
+	// station
+	fromKey := req.From
+	if fromKey == "" {
+		fromKey = dep.Route.Stops[0].Station
+	}
+	from, ok := s.catalog.station(fromKey)
+	var board *routeStop
+	if ok {
+		board, ok = dep.stop(from)
+	}
+	if !ok {
+		return nil, badRequest(fieldViolation("from", fmt.Sprintf("departure %s does not call at %q", dep.ID, fromKey)))
+	}
+	if leaves := dep.stopTime(board); !s.now().Before(leaves) {
+		return nil, errorInfo(codes.FailedPrecondition, reasonAlreadyDeparted, map[string]string{"departure_id": dep.ID},
+			"departure %s left %s at %s", dep.ID, from.Name, leaves.UTC().Format(time.RFC3339))
+	}
+	seats, err := s.seatMap(dep)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	sections := dep.Train.Sections
+	if req.Section != "" {
+		section, ok := seats.section(req.Section)
+		if !ok {
+			return nil, badRequest(fieldViolation("section", fmt.Sprintf("departure %s has no section %q", dep.ID, req.Section)))
+		}
+		sections = []*sectionLayout{section}
+	}
+
+	// Only passengers who could not otherwise travel may queue
+	if err := s.checkNotBooked(req.User.Email, dep.ID); err != nil {
+		return nil, err
+	}
+	for _, entry := range s.waitlist {
+		if entry.departureID == dep.ID && entry.user.Email == req.User.Email {
+			return nil, errorInfo(codes.AlreadyExists, reasonAlreadyWaitlisted,
+				map[string]string{"departure_id": dep.ID, "entry_id": entry.id},
+				"%s is already on the waitlist for departure %s as %s", req.User.Email, dep.ID, entry.id)
+		}
+	}
+	for _, section := range sections {
+		if len(seats.freeSeats(section)) > 0 {
+			return nil, errorInfo(codes.FailedPrecondition, reasonSeatsAvailable,
+				map[string]string{"departure_id": dep.ID, "section": section.Name},
+				"departure %s has free seats in %s", dep.ID, section.Name)
+		}
+	}
+
+	// Passengers cannot jump the queue: only callers who may queue anyone,
+	// such as station agents, set a priority
+	priority := req.Priority
+	if id := callerIdentity(ctx); id != nil && !hasRole(id, s.policy.rule("JoinWaitlist").Roles) {
+		priority = 0
+	}
+
+	// Entry IDs are random, like hold IDs, so that nobody can guess other
+	// passengers' entries
+	s.nextWaitlistEntry++
+	entry := &waitlistEntry{
+		id:          randomID("wait-"),
+		departureID: dep.ID,
+		section:     req.Section,
+		user:        req.User,
+		priority:    priority,
+		seq:         s.nextWaitlistEntry,
+		joinedAt:    s.now(),
+		status:      pb.WaitlistStatus_WAITLIST_STATUS_WAITING,
+		changed:     make(chan struct{}),
+	}
+	s.waitlist = append(s.waitlist, entry)
+	s.notifyWaitlist(dep.ID)
+
+	return &pb.JoinWaitlistResponse{Entry: s.waitlistEntryProto(entry)}, nil
+}
+
+func (s *server) LeaveWaitlist(ctx context.Context, req *pb.LeaveWaitlistRequest) (*pb.LeaveWaitlistResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := validateEntryRequest(req.EntryId, req.Email); err != nil {
+		return nil, err
+	}
+	entry, err := s.waitlistEntry(req.EntryId)
+	if err != nil {
+		return nil, err
+	}
+	if err := entry.checkOwner(req.Email); err != nil {
+		return nil, err
+	}
+
+	// A promoted passenger gives up their held seat to the next in line
+	if hold, ok := s.holds[entry.holdID]; ok {
+		if hold.receiptID != "" {
+			return nil, errorInfo(codes.FailedPrecondition, reasonHoldInUse, map[string]string{"hold_id": hold.id, "receipt_id": hold.receiptID},
+				"hold %q is being purchased as %s", hold.id, hold.receiptID)
+		}
+		delete(s.holds, hold.id)
+	}
+	s.finishWaitlistEntry(entry, pb.WaitlistStatus_WAITLIST_STATUS_LEFT)
+	if err := s.promoteWaitlist(entry.departureID); err != nil {
+		return nil, err
+	}
+	s.publishSeats(entry.departureID)
+
+	return &pb.LeaveWaitlistResponse{Success: true}, nil
+}
+
+func (s *server) WatchWaitlist(req *pb.WatchWaitlistRequest, stream pb.TicketService_WatchWaitlistServer) error {
+	if err := validateEntryRequest(req.EntryId, req.Email); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	entry, err := s.waitlistEntry(req.EntryId)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := entry.checkOwner(req.Email); err != nil {
+		return err
+	}
+
+	var last *pb.WaitlistEntry
+	for {
+		s.mu.Lock()
+		current := s.waitlistEntryProto(entry)
+		changed := entry.changed
+		s.mu.Unlock()
+
+		if !proto.Equal(current, last) {
+			if err := stream.Send(current); err != nil {
+				return err
+			}
+			last = current
+		}
+		if current.Status != pb.WaitlistStatus_WAITLIST_STATUS_WAITING {
+			return nil
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-changed:
+		}
+	}
+}
+
+// waitlistEntry returns the entry entryID that is still waiting or promoted.
+func (s *server) waitlistEntry(entryID string) (*waitlistEntry, error) {
+	for _, entry := range s.waitlist {
+		if entry.id == entryID {
+			return entry, nil
+		}
+	}
+	return nil, errorInfo(codes.NotFound, reasonWaitlistEntryNotFound, map[string]string{"entry_id": entryID},
+		"waitlist entry %q not found", entryID)
+}
+
+// validateEntryRequest checks that a request naming a waitlist entry gives
+// both its ID and the passenger's email.
+func validateEntryRequest(entryID, email string) error {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if entryID == "" {
+		violations = append(violations, fieldViolation("entry_id", "entry_id is required"))
+	}
+	if email == "" {
+		violations = append(violations, fieldViolation("email", "email is required"))
+	}
+	if len(violations) > 0 {
+		return badRequest(violations...)
+	}
+	return nil
+}
+
+// checkOwner fails with PERMISSION_DENIED unless the entry is email's.
+func (e *waitlistEntry) checkOwner(email string) error {
+	if email == e.user.GetEmail() {
+		return nil
+	}
+	return errorInfo(codes.PermissionDenied, reasonNotOwner, map[string]string{"entry_id": e.id},
+		"waitlist entry %s belongs to another passenger", e.id)
+}
+
+// waitlistEntryFor returns the entry promoted to holdID, or nil.
+func (s *server) waitlistEntryFor(holdID string) *waitlistEntry {
+	for _, entry := range s.waitlist {
+		if entry.holdID == holdID {
+			return entry
+		}
+	}
+	return nil
+}
+
+// waitingEntries returns the entries still waiting for a seat on departureID,
+// in the order they will be served.
+func (s *server) waitingEntries(departureID string) []*waitlistEntry {
+	var waiting []*waitlistEntry
+	for _, entry := range s.waitlist {
+		if entry.departureID == departureID && entry.status == pb.WaitlistStatus_WAITLIST_STATUS_WAITING {
+			waiting = append(waiting, entry)
+		}
+	}
+	sort.Slice(waiting, func(i, j int) bool { return waiting[i].ahead(waiting[j]) })
+	return waiting
+}
+
+// promoteWaitlist gives the free seats on departureID to the passengers at
+// the front of its waitlist, holding one seat for each until the hold expires.
+func (s *server) promoteWaitlist(departureID string) error {
+	waiting := s.waitingEntries(departureID)
+	if len(waiting) == 0 {
+		return nil
+	}
+	dep, ok := s.catalog.departure(departureID)
+	if !ok {
+		return nil
+	}
+	seats, err := s.seatMap(dep)
+	if err != nil {
+		return storeError(err)
+	}
+
+	promoted := false
+	for _, entry := range waiting {
+		sections := dep.Train.Sections
+		if section, ok := seats.section(entry.section); ok {
+			sections = []*sectionLayout{section}
+		}
+		for _, section := range sections {
+			if free := seats.freeSeats(section); len(free) > 0 {
+				hold := s.newHold(dep.ID, free[:1], entry.user.Email)
+				seats.occupied[free[0]] = hold.id
+				entry.status = pb.WaitlistStatus_WAITLIST_STATUS_PROMOTED
+				entry.holdID = hold.id
+				promoted = true
+				break
+			}
+		}
+	}
+	if promoted {
+		s.notifyWaitlist(departureID)
+	}
+	return nil
+}
+
+// finishWaitlistEntry takes entry off the waitlist with its final status.
+func (s *server) finishWaitlistEntry(entry *waitlistEntry, status pb.WaitlistStatus) {
+	entry.status = status
+	for i, e := range s.waitlist {
+		if e == entry {
+			s.waitlist = append(s.waitlist[:i], s.waitlist[i+1:]...)
+			break
+		}
+	}
+	s.notifyWaitlist(entry.departureID)
+	// Watchers of an entry no longer in the queue would otherwise miss the change
+	entry.notify()
+}
+
+// notifyWaitlist wakes the watchers of every entry on departureID, whose
+// positions may have moved.
+func (s *server) notifyWaitlist(departureID string) {
+	for _, entry := range s.waitlist {
+		if entry.departureID == departureID {
+			entry.notify()
+		}
+	}
+}
+
+func (e *waitlistEntry) notify() {
+	close(e.changed)
+	e.changed = make(chan struct{})
+}
+
+func (s *server) waitlistEntryProto(entry *waitlistEntry) *pb.WaitlistEntry {
+	pbEntry := &pb.WaitlistEntry{
+		EntryId:     entry.id,
+		DepartureId: entry.departureID,
+		Section:     entry.section,
+		User:        entry.user,
+		Priority:    entry.priority,
+		Status:      entry.status,
+		JoinedAt:    timestamppb.New(entry.joinedAt),
+	}
+	if entry.status == pb.WaitlistStatus_WAITLIST_STATUS_WAITING {
+		for i, e := range s.waitingEntries(entry.departureID) {
+			if e == entry {
+				pbEntry.Position = int32(i + 1)
+			}
+		}
+	}
+	if hold, ok := s.holds[entry.holdID]; ok {
+		pbEntry.Hold = hold.toProto()
+	}
+	return pbEntry
+}
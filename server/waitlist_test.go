@@ -0,0 +1,216 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/Aravinthvvs/gRPC/proto/train/train"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func joinWaitlist(t *testing.T, s *server, email, section string, priority int32) *pb.WaitlistEntry {
+	resp, err := s.JoinWaitlist(context.Background(), &pb.JoinWaitlistRequest{
+		DepartureId: testDepartureID,
+		Section:     section,
+		User:        &pb.User{Email: email},
+		Priority:    priority,
+	})
+	require.NoError(t, err)
+	return resp.Entry
+}
+
+// waitlistEntryState returns the current state of entryID as a WaitlistEntry.
+func waitlistEntryState(t *testing.T, s *server, entryID string) *pb.WaitlistEntry {
+	entry, err := s.waitlistEntry(entryID)
+	require.NoError(t, err)
+	return s.waitlistEntryProto(entry)
+}
+
+func TestWaitlistPromotion(t *testing.T) {
+	s := newSmallTrainServer()
+	ctx := context.Background()
+	now := time.Date(2030, 8, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	// The waitlist only opens once the departure is sold out
+	_, err := s.JoinWaitlist(ctx, &pb.JoinWaitlistRequest{DepartureId: testDepartureID, User: &pb.User{Email: "ann@example.com"}})
+	_, reason := errorDetails(err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, reasonSeatsAvailable, reason)
+	assert.Equal(t, []string{"A1", "B1", "A2", "B2"}, purchaseSeats(t, s, "", "", "", ""))
+
+	ann := joinWaitlist(t, s, "ann@example.com", "SectionA", 0)
+	bob := joinWaitlist(t, s, "bob@example.com", "", 0)
+	cat := joinWaitlist(t, s, "cat@example.com", "", 1)
+	assert.Equal(t, int32(2), waitlistEntryState(t, s, ann.EntryId).Position)
+	assert.Equal(t, int32(3), waitlistEntryState(t, s, bob.EntryId).Position)
+	assert.Equal(t, int32(1), cat.Position)
+	_, err = s.JoinWaitlist(ctx, &pb.JoinWaitlistRequest{DepartureId: testDepartureID, User: &pb.User{Email: "ann@example.com"}})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// Cat has priority, and Ann only wants SectionA, so Cat gets B1
+	_, err = s.RemoveUser(ctx, &pb.RemoveUserRequest{Email: "passenger1@example.com"})
+	require.NoError(t, err)
+	catState := waitlistEntryState(t, s, cat.EntryId)
+	assert.Equal(t, pb.WaitlistStatus_WAITLIST_STATUS_PROMOTED, catState.Status)
+	assert.Equal(t, []string{"B1"}, catState.Hold.Seats)
+	assert.Equal(t, "cat@example.com", catState.Hold.ReservedFor)
+	assert.Equal(t, int32(1), waitlistEntryState(t, s, ann.EntryId).Position)
+
+	// A1 goes to Ann, and nobody else can buy it
+	_, err = s.CancelTicket(ctx, &pb.CancelTicketRequest{ReceiptId: "rec-1"})
+	require.NoError(t, err)
+	annState := waitlistEntryState(t, s, ann.EntryId)
+	assert.Equal(t, []string{"A1"}, annState.Hold.Seats)
+	_, err = purchaseWithToken(s, "dan@example.com", "A1", "")
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	purchase := &pb.PurchaseRequest{
+		From:        "London",
+		To:          "Paris",
+		DepartureId: testDepartureID,
+		User:        &pb.User{Email: "bob@example.com"},
+		HoldId:      annState.Hold.HoldId,
+	}
+	_, err = s.PurchaseTicket(ctx, purchase)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	purchase.User = &pb.User{Email: "ann@example.com"}
+	_, err = s.PurchaseTicket(ctx, purchase)
+	require.NoError(t, err)
+	_, err = s.LeaveWaitlist(ctx, &pb.LeaveWaitlistRequest{EntryId: ann.EntryId, Email: "ann@example.com"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Cat lets the hold lapse and B1 passes to Bob
+	now = now.Add(s.holdTTL)
+	_, err = s.expireHolds(now)
+	require.NoError(t, err)
+	bobState := waitlistEntryState(t, s, bob.EntryId)
+	assert.Equal(t, pb.WaitlistStatus_WAITLIST_STATUS_PROMOTED, bobState.Status)
+	assert.Equal(t, []string{"B1"}, bobState.Hold.Seats)
+	_, err = s.waitlistEntry(cat.EntryId)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestWaitlistPriorityFromStaff(t *testing.T) {
+	s := newSmallTrainServer()
+	purchaseSeats(t, s, "", "", "", "")
+	join := func(email, role string) *pb.WaitlistEntry {
+		ctx := withIdentity(context.Background(), &identity{subject: email, email: email, roles: []string{role}})
+		resp, err := s.JoinWaitlist(ctx, &pb.JoinWaitlistRequest{DepartureId: testDepartureID, User: &pb.User{Email: email}, Priority: 5})
+		require.NoError(t, err)
+		return resp.Entry
+	}
+
+	// Passengers asking for priority join at the default
+	assert.Equal(t, int32(0), join("ann@example.com", rolePassenger).Priority)
+	bob := join("bob@example.com", roleAgent)
+	assert.Equal(t, int32(5), bob.Priority)
+	assert.Equal(t, int32(1), bob.Position)
+}
+
+func TestLeaveWaitlist(t *testing.T) {
+	s := newSmallTrainServer()
+	ctx := context.Background()
+	purchaseSeats(t, s, "", "", "", "")
+	ann := joinWaitlist(t, s, "ann@example.com", "", 0)
+	bob := joinWaitlist(t, s, "bob@example.com", "", 0)
+
+	// Entry IDs cannot be guessed, and only their passenger can leave them
+	assert.Regexp(t, "^wait-[a-z2-7]{16}$", ann.EntryId)
+	_, err := s.LeaveWaitlist(ctx, &pb.LeaveWaitlistRequest{EntryId: ann.EntryId, Email: "bob@example.com"})
+	_, reason := errorDetails(err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, reasonNotOwner, reason)
+
+	// Leaving after promotion hands the seat to the next passenger
+	_, err = s.RemoveUser(ctx, &pb.RemoveUserRequest{Email: "passenger0@example.com"})
+	require.NoError(t, err)
+	resp, err := s.LeaveWaitlist(ctx, &pb.LeaveWaitlistRequest{EntryId: ann.EntryId, Email: "ann@example.com"})
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+	bobState := waitlistEntryState(t, s, bob.EntryId)
+	assert.Equal(t, pb.WaitlistStatus_WAITLIST_STATUS_PROMOTED, bobState.Status)
+	assert.Equal(t, []string{"A1"}, bobState.Hold.Seats)
+
+	_, err = s.LeaveWaitlist(ctx, &pb.LeaveWaitlistRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	fields, _ := errorDetails(err)
+	assert.Equal(t, []string{"entry_id", "email"}, fields)
+}
+
+// waitlistStream collects the entries sent by WatchWaitlist.
+type waitlistStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pb.WaitlistEntry
+}
+
+func (w *waitlistStream) Context() context.Context { return w.ctx }
+
+func (w *waitlistStream) Send(entry *pb.WaitlistEntry) error {
+	w.sent <- entry
+	return nil
+}
+
+func TestWatchWaitlist(t *testing.T) {
+	s := newSmallTrainServer()
+	purchaseSeats(t, s, "", "", "", "")
+	ann := joinWaitlist(t, s, "ann@example.com", "", 0)
+	bob := joinWaitlist(t, s, "bob@example.com", "", 0)
+
+	// Nobody else can watch Bob's entry for the hold it is promoted to
+	err := s.WatchWaitlist(&pb.WatchWaitlistRequest{EntryId: bob.EntryId, Email: "ann@example.com"}, &waitlistStream{ctx: context.Background()})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	stream := &waitlistStream{ctx: context.Background(), sent: make(chan *pb.WaitlistEntry, 10)}
+	done := make(chan error)
+	go func() {
+		done <- s.WatchWaitlist(&pb.WatchWaitlistRequest{EntryId: bob.EntryId, Email: "bob@example.com"}, stream)
+	}()
+	assert.Equal(t, int32(2), (<-stream.sent).Position)
+
+	// Bob moves up when Ann leaves, then hears that a seat is waiting
+	_, err = s.LeaveWaitlist(context.Background(), &pb.LeaveWaitlistRequest{EntryId: ann.EntryId, Email: "ann@example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), (<-stream.sent).Position)
+	_, err = s.CancelTicket(context.Background(), &pb.CancelTicketRequest{ReceiptId: "rec-2"})
+	require.NoError(t, err)
+	promoted := <-stream.sent
+	assert.Equal(t, pb.WaitlistStatus_WAITLIST_STATUS_PROMOTED, promoted.Status)
+	assert.Equal(t, []string{"B1"}, promoted.Hold.Seats)
+	require.NoError(t, <-done)
+}
+
+func TestJoinWaitlistDeparted(t *testing.T) {
+	s := newSmallTrainServer()
+	ctx := context.Background()
+	purchaseSeats(t, s, "", "", "", "")
+	// ES9010 leaves London at 08:01 and calls at Lille at 09:23
+	now := time.Date(2030, 9, 1, 8, 30, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	join := func(from string) error {
+		_, err := s.JoinWaitlist(ctx, &pb.JoinWaitlistRequest{DepartureId: testDepartureID, From: from, User: &pb.User{Email: "ann@example.com"}})
+		return err
+	}
+
+	err := join("")
+	_, reason := errorDetails(err)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	assert.Equal(t, reasonAlreadyDeparted, reason)
+	err = join("London")
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	err = join("Atlantis")
+	fields, _ := errorDetails(err)
+	assert.Equal(t, []string{"from"}, fields)
+
+	// Passengers further down the line can still wait for it
+	require.NoError(t, join("Lille"))
+	now = time.Date(2030, 9, 1, 9, 23, 0, 0, time.UTC)
+	_, err = s.JoinWaitlist(ctx, &pb.JoinWaitlistRequest{DepartureId: testDepartureID, From: "Lille", User: &pb.User{Email: "bob@example.com"}})
+	_, reason = errorDetails(err)
+	assert.Equal(t, reasonAlreadyDeparted, reason)
+}